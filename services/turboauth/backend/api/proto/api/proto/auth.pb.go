@@ -71,6 +71,8 @@ type GetStatusResponse struct {
 	TrustScore      int32                  `protobuf:"varint,2,opt,name=trust_score,json=trustScore,proto3" json:"trust_score,omitempty"`               // 0-100
 	UpdatedAt       int64                  `protobuf:"varint,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                  // Unix timestamp
 	ContractAddress string                 `protobuf:"bytes,4,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"` // Smart contract address
+	Stale           bool                   `protobuf:"varint,5,opt,name=stale,proto3" json:"stale,omitempty"`                                           // Served from cache past its TTL, not yet refreshed
+	WalletAddress   string                 `protobuf:"bytes,6,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -133,12 +135,29 @@ func (x *GetStatusResponse) GetContractAddress() string {
 	return ""
 }
 
+func (x *GetStatusResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *GetStatusResponse) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
 type SetStatusRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	WalletAddress  string                 `protobuf:"bytes,1,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
 	Status         string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
 	TrustScore     int32                  `protobuf:"varint,3,opt,name=trust_score,json=trustScore,proto3" json:"trust_score,omitempty"`
-	AdminSignature string                 `protobuf:"bytes,4,opt,name=admin_signature,json=adminSignature,proto3" json:"admin_signature,omitempty"` // Admin authorization
+	AdminSignature string                 `protobuf:"bytes,4,opt,name=admin_signature,json=adminSignature,proto3" json:"admin_signature,omitempty"` // Admin signature over the canonical request
+	AdminAddress   string                 `protobuf:"bytes,5,opt,name=admin_address,json=adminAddress,proto3" json:"admin_address,omitempty"`       // Admin identity that signed the request
+	Nonce          string                 `protobuf:"bytes,6,opt,name=nonce,proto3" json:"nonce,omitempty"`                                         // Single-use nonce chosen by the admin
+	Timestamp      int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                // Unix seconds when the request was signed
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -201,6 +220,27 @@ func (x *SetStatusRequest) GetAdminSignature() string {
 	return ""
 }
 
+func (x *SetStatusRequest) GetAdminAddress() string {
+	if x != nil {
+		return x.AdminAddress
+	}
+	return ""
+}
+
+func (x *SetStatusRequest) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *SetStatusRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
 type SetStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -261,29 +301,31 @@ func (x *SetStatusResponse) GetMessage() string {
 	return ""
 }
 
-type VerifyWalletRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	WalletAddress string                 `protobuf:"bytes,1,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
-	Signature     string                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ApproveProposalRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ProposalId     string                 `protobuf:"bytes,1,opt,name=proposal_id,json=proposalId,proto3" json:"proposal_id,omitempty"`
+	AdminAddress   string                 `protobuf:"bytes,2,opt,name=admin_address,json=adminAddress,proto3" json:"admin_address,omitempty"`       // Admin identity that signed the approval
+	Nonce          string                 `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`                                         // Single-use nonce chosen by the admin
+	Timestamp      int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                // Unix seconds when the approval was signed
+	AdminSignature string                 `protobuf:"bytes,5,opt,name=admin_signature,json=adminSignature,proto3" json:"admin_signature,omitempty"` // Admin signature over the canonical approval
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
-func (x *VerifyWalletRequest) Reset() {
-	*x = VerifyWalletRequest{}
+func (x *ApproveProposalRequest) Reset() {
+	*x = ApproveProposalRequest{}
 	mi := &file_api_proto_auth_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyWalletRequest) String() string {
+func (x *ApproveProposalRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyWalletRequest) ProtoMessage() {}
+func (*ApproveProposalRequest) ProtoMessage() {}
 
-func (x *VerifyWalletRequest) ProtoReflect() protoreflect.Message {
+func (x *ApproveProposalRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_auth_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -295,55 +337,67 @@ func (x *VerifyWalletRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyWalletRequest.ProtoReflect.Descriptor instead.
-func (*VerifyWalletRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ApproveProposalRequest.ProtoReflect.Descriptor instead.
+func (*ApproveProposalRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_auth_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *VerifyWalletRequest) GetWalletAddress() string {
+func (x *ApproveProposalRequest) GetProposalId() string {
 	if x != nil {
-		return x.WalletAddress
+		return x.ProposalId
 	}
 	return ""
 }
 
-func (x *VerifyWalletRequest) GetSignature() string {
+func (x *ApproveProposalRequest) GetAdminAddress() string {
 	if x != nil {
-		return x.Signature
+		return x.AdminAddress
 	}
 	return ""
 }
 
-func (x *VerifyWalletRequest) GetMessage() string {
+func (x *ApproveProposalRequest) GetNonce() string {
 	if x != nil {
-		return x.Message
+		return x.Nonce
 	}
 	return ""
 }
 
-type VerifyWalletResponse struct {
+func (x *ApproveProposalRequest) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *ApproveProposalRequest) GetAdminSignature() string {
+	if x != nil {
+		return x.AdminSignature
+	}
+	return ""
+}
+
+type GetProposalRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Verified      bool                   `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
-	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	TrustScore    int32                  `protobuf:"varint,3,opt,name=trust_score,json=trustScore,proto3" json:"trust_score,omitempty"`
+	ProposalId    string                 `protobuf:"bytes,1,opt,name=proposal_id,json=proposalId,proto3" json:"proposal_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VerifyWalletResponse) Reset() {
-	*x = VerifyWalletResponse{}
+func (x *GetProposalRequest) Reset() {
+	*x = GetProposalRequest{}
 	mi := &file_api_proto_auth_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyWalletResponse) String() string {
+func (x *GetProposalRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyWalletResponse) ProtoMessage() {}
+func (*GetProposalRequest) ProtoMessage() {}
 
-func (x *VerifyWalletResponse) ProtoReflect() protoreflect.Message {
+func (x *GetProposalRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_auth_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -355,54 +409,104 @@ func (x *VerifyWalletResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyWalletResponse.ProtoReflect.Descriptor instead.
-func (*VerifyWalletResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetProposalRequest.ProtoReflect.Descriptor instead.
+func (*GetProposalRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_auth_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *VerifyWalletResponse) GetVerified() bool {
+func (x *GetProposalRequest) GetProposalId() string {
 	if x != nil {
-		return x.Verified
+		return x.ProposalId
 	}
-	return false
+	return ""
 }
 
-func (x *VerifyWalletResponse) GetStatus() string {
+type Approval struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminAddress  string                 `protobuf:"bytes,1,opt,name=admin_address,json=adminAddress,proto3" json:"admin_address,omitempty"`
+	ApprovedAt    int64                  `protobuf:"varint,2,opt,name=approved_at,json=approvedAt,proto3" json:"approved_at,omitempty"` // Unix timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Approval) Reset() {
+	*x = Approval{}
+	mi := &file_api_proto_auth_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Approval) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Approval) ProtoMessage() {}
+
+func (x *Approval) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[6]
 	if x != nil {
-		return x.Status
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Approval.ProtoReflect.Descriptor instead.
+func (*Approval) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Approval) GetAdminAddress() string {
+	if x != nil {
+		return x.AdminAddress
 	}
 	return ""
 }
 
-func (x *VerifyWalletResponse) GetTrustScore() int32 {
+func (x *Approval) GetApprovedAt() int64 {
 	if x != nil {
-		return x.TrustScore
+		return x.ApprovedAt
 	}
 	return 0
 }
 
-type BatchGetStatusRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	WalletAddresses []string               `protobuf:"bytes,1,rep,name=wallet_addresses,json=walletAddresses,proto3" json:"wallet_addresses,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type ProposalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProposalId    string                 `protobuf:"bytes,1,opt,name=proposal_id,json=proposalId,proto3" json:"proposal_id,omitempty"`
+	WalletAddress string                 `protobuf:"bytes,2,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`                            // Proposed status
+	TrustScore    int32                  `protobuf:"varint,4,opt,name=trust_score,json=trustScore,proto3" json:"trust_score,omitempty"` // Proposed trust score
+	ProposedBy    string                 `protobuf:"bytes,5,opt,name=proposed_by,json=proposedBy,proto3" json:"proposed_by,omitempty"`
+	Approvals     []*Approval            `protobuf:"bytes,6,rep,name=approvals,proto3" json:"approvals,omitempty"`
+	Threshold     int32                  `protobuf:"varint,7,opt,name=threshold,proto3" json:"threshold,omitempty"`                   // Approvals needed
+	State         string                 `protobuf:"bytes,8,opt,name=state,proto3" json:"state,omitempty"`                            // PENDING, APPROVED, SUBMITTED, FAILED, EXPIRED
+	TxHash        string                 `protobuf:"bytes,9,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`            // Set once submitted
+	Error         string                 `protobuf:"bytes,10,opt,name=error,proto3" json:"error,omitempty"`                           // Submission failure reason
+	CreatedAt     int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix timestamp
+	ExpiresAt     int64                  `protobuf:"varint,12,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix timestamp
+	UpdatedAt     int64                  `protobuf:"varint,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"` // Unix timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchGetStatusRequest) Reset() {
-	*x = BatchGetStatusRequest{}
-	mi := &file_api_proto_auth_proto_msgTypes[6]
+func (x *ProposalResponse) Reset() {
+	*x = ProposalResponse{}
+	mi := &file_api_proto_auth_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchGetStatusRequest) String() string {
+func (x *ProposalResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchGetStatusRequest) ProtoMessage() {}
+func (*ProposalResponse) ProtoMessage() {}
 
-func (x *BatchGetStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_auth_proto_msgTypes[6]
+func (x *ProposalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -413,40 +517,127 @@ func (x *BatchGetStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchGetStatusRequest.ProtoReflect.Descriptor instead.
-func (*BatchGetStatusRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_auth_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ProposalResponse.ProtoReflect.Descriptor instead.
+func (*ProposalResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *BatchGetStatusRequest) GetWalletAddresses() []string {
+func (x *ProposalResponse) GetProposalId() string {
 	if x != nil {
-		return x.WalletAddresses
+		return x.ProposalId
+	}
+	return ""
+}
+
+func (x *ProposalResponse) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *ProposalResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProposalResponse) GetTrustScore() int32 {
+	if x != nil {
+		return x.TrustScore
+	}
+	return 0
+}
+
+func (x *ProposalResponse) GetProposedBy() string {
+	if x != nil {
+		return x.ProposedBy
+	}
+	return ""
+}
+
+func (x *ProposalResponse) GetApprovals() []*Approval {
+	if x != nil {
+		return x.Approvals
 	}
 	return nil
 }
 
-type BatchGetStatusResponse struct {
+func (x *ProposalResponse) GetThreshold() int32 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
+func (x *ProposalResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *ProposalResponse) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *ProposalResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ProposalResponse) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ProposalResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ProposalResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+type CreateChallengeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Statuses      []*GetStatusResponse   `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	Domain        string                 `protobuf:"bytes,2,opt,name=domain,proto3" json:"domain,omitempty"` // Relying party domain, must be allowed
+	Uri           string                 `protobuf:"bytes,3,opt,name=uri,proto3" json:"uri,omitempty"`       // URI the sign-in is for
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"` // Requested scopes
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BatchGetStatusResponse) Reset() {
-	*x = BatchGetStatusResponse{}
-	mi := &file_api_proto_auth_proto_msgTypes[7]
+func (x *CreateChallengeRequest) Reset() {
+	*x = CreateChallengeRequest{}
+	mi := &file_api_proto_auth_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BatchGetStatusResponse) String() string {
+func (x *CreateChallengeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BatchGetStatusResponse) ProtoMessage() {}
+func (*CreateChallengeRequest) ProtoMessage() {}
 
-func (x *BatchGetStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_auth_proto_msgTypes[7]
+func (x *CreateChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,60 +648,1393 @@ func (x *BatchGetStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BatchGetStatusResponse.ProtoReflect.Descriptor instead.
-func (*BatchGetStatusResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_auth_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use CreateChallengeRequest.ProtoReflect.Descriptor instead.
+func (*CreateChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *BatchGetStatusResponse) GetStatuses() []*GetStatusResponse {
+func (x *CreateChallengeRequest) GetWalletAddress() string {
 	if x != nil {
-		return x.Statuses
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *CreateChallengeRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CreateChallengeRequest) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+func (x *CreateChallengeRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
 	}
 	return nil
 }
 
-var File_api_proto_auth_proto protoreflect.FileDescriptor
+type CreateChallengeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nonce         string                 `protobuf:"bytes,1,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	WalletAddress string                 `protobuf:"bytes,2,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`                       // Sign-in message to sign
+	IssuedAt      int64                  `protobuf:"varint,4,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`    // Unix timestamp
+	ExpiresAt     int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix timestamp
+	Domain        string                 `protobuf:"bytes,6,opt,name=domain,proto3" json:"domain,omitempty"`
+	Scopes        []string               `protobuf:"bytes,7,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_api_proto_auth_proto_rawDesc = "" +
-	"\n" +
-	"\x14api/proto/auth.proto\x12\aauth.v1\"9\n" +
-	"\x10GetStatusRequest\x12%\n" +
-	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\"\x96\x01\n" +
-	"\x11GetStatusResponse\x12\x16\n" +
-	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1f\n" +
-	"\vtrust_score\x18\x02 \x01(\x05R\n" +
-	"trustScore\x12\x1d\n" +
-	"\n" +
-	"updated_at\x18\x03 \x01(\x03R\tupdatedAt\x12)\n" +
-	"\x10contract_address\x18\x04 \x01(\tR\x0fcontractAddress\"\x9b\x01\n" +
-	"\x10SetStatusRequest\x12%\n" +
-	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
-	"\vtrust_score\x18\x03 \x01(\x05R\n" +
-	"trustScore\x12'\n" +
-	"\x0fadmin_signature\x18\x04 \x01(\tR\x0eadminSignature\"`\n" +
-	"\x11SetStatusResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x17\n" +
-	"\atx_hash\x18\x02 \x01(\tR\x06txHash\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"t\n" +
-	"\x13VerifyWalletRequest\x12%\n" +
-	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\x12\x1c\n" +
-	"\tsignature\x18\x02 \x01(\tR\tsignature\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"k\n" +
-	"\x14VerifyWalletResponse\x12\x1a\n" +
-	"\bverified\x18\x01 \x01(\bR\bverified\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
-	"\vtrust_score\x18\x03 \x01(\x05R\n" +
-	"trustScore\"B\n" +
+func (x *CreateChallengeResponse) Reset() {
+	*x = CreateChallengeResponse{}
+	mi := &file_api_proto_auth_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateChallengeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateChallengeResponse) ProtoMessage() {}
+
+func (x *CreateChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateChallengeResponse.ProtoReflect.Descriptor instead.
+func (*CreateChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateChallengeResponse) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *CreateChallengeResponse) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *CreateChallengeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateChallengeResponse) GetIssuedAt() int64 {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return 0
+}
+
+func (x *CreateChallengeResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *CreateChallengeResponse) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CreateChallengeResponse) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+type VerifyWalletRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	Signature     string                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"` // Challenge message from CreateChallenge
+	Domain        string                 `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`   // Optional: expected domain of the message
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyWalletRequest) Reset() {
+	*x = VerifyWalletRequest{}
+	mi := &file_api_proto_auth_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyWalletRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyWalletRequest) ProtoMessage() {}
+
+func (x *VerifyWalletRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyWalletRequest.ProtoReflect.Descriptor instead.
+func (*VerifyWalletRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VerifyWalletRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *VerifyWalletRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *VerifyWalletRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *VerifyWalletRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+type VerifyWalletResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verified      bool                   `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	TrustScore    int32                  `protobuf:"varint,3,opt,name=trust_score,json=trustScore,proto3" json:"trust_score,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyWalletResponse) Reset() {
+	*x = VerifyWalletResponse{}
+	mi := &file_api_proto_auth_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyWalletResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyWalletResponse) ProtoMessage() {}
+
+func (x *VerifyWalletResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyWalletResponse.ProtoReflect.Descriptor instead.
+func (*VerifyWalletResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VerifyWalletResponse) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+func (x *VerifyWalletResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *VerifyWalletResponse) GetTrustScore() int32 {
+	if x != nil {
+		return x.TrustScore
+	}
+	return 0
+}
+
+type BatchGetStatusRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddresses []string               `protobuf:"bytes,1,rep,name=wallet_addresses,json=walletAddresses,proto3" json:"wallet_addresses,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *BatchGetStatusRequest) Reset() {
+	*x = BatchGetStatusRequest{}
+	mi := &file_api_proto_auth_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetStatusRequest) ProtoMessage() {}
+
+func (x *BatchGetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetStatusRequest.ProtoReflect.Descriptor instead.
+func (*BatchGetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchGetStatusRequest) GetWalletAddresses() []string {
+	if x != nil {
+		return x.WalletAddresses
+	}
+	return nil
+}
+
+type BatchGetStatusResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Successful lookups only; use results, which also reports failures
+	//
+	// Deprecated: Marked as deprecated in api/proto/auth.proto.
+	Statuses []*GetStatusResponse `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	// One entry per distinct wallet, in request order
+	Results       []*WalletStatusResult `protobuf:"bytes,2,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchGetStatusResponse) Reset() {
+	*x = BatchGetStatusResponse{}
+	mi := &file_api_proto_auth_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchGetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchGetStatusResponse) ProtoMessage() {}
+
+func (x *BatchGetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchGetStatusResponse.ProtoReflect.Descriptor instead.
+func (*BatchGetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{13}
+}
+
+// Deprecated: Marked as deprecated in api/proto/auth.proto.
+func (x *BatchGetStatusResponse) GetStatuses() []*GetStatusResponse {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *BatchGetStatusResponse) GetResults() []*WalletStatusResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type WalletStatusResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	Status        *GetStatusResponse     `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`                        // Set when the lookup succeeded
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                          // Set when it failed
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"` // gRPC code name of the failure, e.g. NotFound
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WalletStatusResult) Reset() {
+	*x = WalletStatusResult{}
+	mi := &file_api_proto_auth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WalletStatusResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WalletStatusResult) ProtoMessage() {}
+
+func (x *WalletStatusResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WalletStatusResult.ProtoReflect.Descriptor instead.
+func (*WalletStatusResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WalletStatusResult) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *WalletStatusResult) GetStatus() *GetStatusResponse {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *WalletStatusResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WalletStatusResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type GetTransactionStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxHash        string                 `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionStatusRequest) Reset() {
+	*x = GetTransactionStatusRequest{}
+	mi := &file_api_proto_auth_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionStatusRequest) ProtoMessage() {}
+
+func (x *GetTransactionStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetTransactionStatusRequest) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+type GetTransactionStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TxHash        string                 `protobuf:"bytes,1,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"` // PENDING, CONFIRMED, FAILED
+	WalletAddress string                 `protobuf:"bytes,3,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                               // Requested status
+	TrustScore    int32                  `protobuf:"varint,5,opt,name=trust_score,json=trustScore,proto3" json:"trust_score,omitempty"`    // Requested trust score
+	SubmittedAt   int64                  `protobuf:"varint,6,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"` // Unix timestamp
+	UpdatedAt     int64                  `protobuf:"varint,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`       // Unix timestamp
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`                                 // Failure reason
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionStatusResponse) Reset() {
+	*x = GetTransactionStatusResponse{}
+	mi := &file_api_proto_auth_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionStatusResponse) ProtoMessage() {}
+
+func (x *GetTransactionStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTransactionStatusResponse) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *GetTransactionStatusResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *GetTransactionStatusResponse) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *GetTransactionStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetTransactionStatusResponse) GetTrustScore() int32 {
+	if x != nil {
+		return x.TrustScore
+	}
+	return 0
+}
+
+func (x *GetTransactionStatusResponse) GetSubmittedAt() int64 {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return 0
+}
+
+func (x *GetTransactionStatusResponse) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *GetTransactionStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type Session struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Empty when listing sessions
+	WalletAddress string                 `protobuf:"bytes,2,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`                                    // Empty when listing sessions
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`          // Unix timestamp
+	CreatedAt     int64                  `protobuf:"varint,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`          // Unix timestamp
+	LastActivity  int64                  `protobuf:"varint,6,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"` // Unix timestamp
+	IpAddress     string                 `protobuf:"bytes,7,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`           // Client address the session was created from
+	UserAgent     string                 `protobuf:"bytes,8,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`           // Client user agent the session was created with
+	Handle        string                 `protobuf:"bytes,9,opt,name=handle,proto3" json:"handle,omitempty"`                                  // Names the session when listing sessions
+	Current       bool                   `protobuf:"varint,10,opt,name=current,proto3" json:"current,omitempty"`                              // Set on the listed session of the caller's token
+	RefreshToken  string                 `protobuf:"bytes,11,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Single-use, set when a session is created or refreshed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Session) Reset() {
+	*x = Session{}
+	mi := &file_api_proto_auth_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Session) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Session) ProtoMessage() {}
+
+func (x *Session) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Session.ProtoReflect.Descriptor instead.
+func (*Session) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Session) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Session) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *Session) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *Session) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *Session) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Session) GetLastActivity() int64 {
+	if x != nil {
+		return x.LastActivity
+	}
+	return 0
+}
+
+func (x *Session) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *Session) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *Session) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+func (x *Session) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+func (x *Session) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type CreateSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	Signature     string                 `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"` // Challenge message from CreateChallenge
+	Domain        string                 `protobuf:"bytes,4,opt,name=domain,proto3" json:"domain,omitempty"`   // Optional: expected domain of the message
+	Ttl           int32                  `protobuf:"varint,5,opt,name=ttl,proto3" json:"ttl,omitempty"`        // Session lifetime in seconds, bounded by the server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSessionRequest) Reset() {
+	*x = CreateSessionRequest{}
+	mi := &file_api_proto_auth_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionRequest) ProtoMessage() {}
+
+func (x *CreateSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateSessionRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CreateSessionRequest) GetTtl() int32 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+type CreateSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *Session               `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	Status        *GetStatusResponse     `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSessionResponse) Reset() {
+	*x = CreateSessionResponse{}
+	mi := &file_api_proto_auth_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSessionResponse) ProtoMessage() {}
+
+func (x *CreateSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateSessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateSessionResponse) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+func (x *CreateSessionResponse) GetStatus() *GetStatusResponse {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type RefreshSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,3,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"` // Single-use token from CreateSession or the previous refresh
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshSessionRequest) Reset() {
+	*x = RefreshSessionRequest{}
+	mi := &file_api_proto_auth_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshSessionRequest) ProtoMessage() {}
+
+func (x *RefreshSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshSessionRequest.ProtoReflect.Descriptor instead.
+func (*RefreshSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RefreshSessionRequest) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type DeleteSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Handle        string                 `protobuf:"bytes,1,opt,name=handle,proto3" json:"handle,omitempty"` // A session of the caller's wallet, as listed by GetActiveSessions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSessionRequest) Reset() {
+	*x = DeleteSessionRequest{}
+	mi := &file_api_proto_auth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSessionRequest) ProtoMessage() {}
+
+func (x *DeleteSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSessionRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteSessionRequest) GetHandle() string {
+	if x != nil {
+		return x.Handle
+	}
+	return ""
+}
+
+type DeleteSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSessionResponse) Reset() {
+	*x = DeleteSessionResponse{}
+	mi := &file_api_proto_auth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSessionResponse) ProtoMessage() {}
+
+func (x *DeleteSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSessionResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeleteSessionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetActiveSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActiveSessionsRequest) Reset() {
+	*x = GetActiveSessionsRequest{}
+	mi := &file_api_proto_auth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActiveSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActiveSessionsRequest) ProtoMessage() {}
+
+func (x *GetActiveSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActiveSessionsRequest.ProtoReflect.Descriptor instead.
+func (*GetActiveSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetActiveSessionsRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+type GetActiveSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*Session             `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActiveSessionsResponse) Reset() {
+	*x = GetActiveSessionsResponse{}
+	mi := &file_api_proto_auth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActiveSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActiveSessionsResponse) ProtoMessage() {}
+
+func (x *GetActiveSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActiveSessionsResponse.ProtoReflect.Descriptor instead.
+func (*GetActiveSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetActiveSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type EndAllSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WalletAddress string                 `protobuf:"bytes,1,opt,name=wallet_address,json=walletAddress,proto3" json:"wallet_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndAllSessionsRequest) Reset() {
+	*x = EndAllSessionsRequest{}
+	mi := &file_api_proto_auth_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndAllSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndAllSessionsRequest) ProtoMessage() {}
+
+func (x *EndAllSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndAllSessionsRequest.ProtoReflect.Descriptor instead.
+func (*EndAllSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *EndAllSessionsRequest) GetWalletAddress() string {
+	if x != nil {
+		return x.WalletAddress
+	}
+	return ""
+}
+
+type EndAllSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionsEnded int32                  `protobuf:"varint,1,opt,name=sessions_ended,json=sessionsEnded,proto3" json:"sessions_ended,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EndAllSessionsResponse) Reset() {
+	*x = EndAllSessionsResponse{}
+	mi := &file_api_proto_auth_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EndAllSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EndAllSessionsResponse) ProtoMessage() {}
+
+func (x *EndAllSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EndAllSessionsResponse.ProtoReflect.Descriptor instead.
+func (*EndAllSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *EndAllSessionsResponse) GetSessionsEnded() int32 {
+	if x != nil {
+		return x.SessionsEnded
+	}
+	return 0
+}
+
+type RevokeTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokenRequest) Reset() {
+	*x = RevokeTokenRequest{}
+	mi := &file_api_proto_auth_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenRequest) ProtoMessage() {}
+
+func (x *RevokeTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeTokenRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RevokeTokenRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type RevokeTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeTokenResponse) Reset() {
+	*x = RevokeTokenResponse{}
+	mi := &file_api_proto_auth_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeTokenResponse) ProtoMessage() {}
+
+func (x *RevokeTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeTokenResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *RevokeTokenResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+var File_api_proto_auth_proto protoreflect.FileDescriptor
+
+const file_api_proto_auth_proto_rawDesc = "" +
+	"\n" +
+	"\x14api/proto/auth.proto\x12\aauth.v1\"9\n" +
+	"\x10GetStatusRequest\x12%\n" +
+	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\"\xd3\x01\n" +
+	"\x11GetStatusResponse\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1f\n" +
+	"\vtrust_score\x18\x02 \x01(\x05R\n" +
+	"trustScore\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\x03R\tupdatedAt\x12)\n" +
+	"\x10contract_address\x18\x04 \x01(\tR\x0fcontractAddress\x12\x14\n" +
+	"\x05stale\x18\x05 \x01(\bR\x05stale\x12%\n" +
+	"\x0ewallet_address\x18\x06 \x01(\tR\rwalletAddress\"\xf4\x01\n" +
+	"\x10SetStatusRequest\x12%\n" +
+	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
+	"\vtrust_score\x18\x03 \x01(\x05R\n" +
+	"trustScore\x12'\n" +
+	"\x0fadmin_signature\x18\x04 \x01(\tR\x0eadminSignature\x12#\n" +
+	"\radmin_address\x18\x05 \x01(\tR\fadminAddress\x12\x14\n" +
+	"\x05nonce\x18\x06 \x01(\tR\x05nonce\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x03R\ttimestamp\"`\n" +
+	"\x11SetStatusResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x17\n" +
+	"\atx_hash\x18\x02 \x01(\tR\x06txHash\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\xbb\x01\n" +
+	"\x16ApproveProposalRequest\x12\x1f\n" +
+	"\vproposal_id\x18\x01 \x01(\tR\n" +
+	"proposalId\x12#\n" +
+	"\radmin_address\x18\x02 \x01(\tR\fadminAddress\x12\x14\n" +
+	"\x05nonce\x18\x03 \x01(\tR\x05nonce\x12\x1c\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12'\n" +
+	"\x0fadmin_signature\x18\x05 \x01(\tR\x0eadminSignature\"5\n" +
+	"\x12GetProposalRequest\x12\x1f\n" +
+	"\vproposal_id\x18\x01 \x01(\tR\n" +
+	"proposalId\"P\n" +
+	"\bApproval\x12#\n" +
+	"\radmin_address\x18\x01 \x01(\tR\fadminAddress\x12\x1f\n" +
+	"\vapproved_at\x18\x02 \x01(\x03R\n" +
+	"approvedAt\"\xa5\x03\n" +
+	"\x10ProposalResponse\x12\x1f\n" +
+	"\vproposal_id\x18\x01 \x01(\tR\n" +
+	"proposalId\x12%\n" +
+	"\x0ewallet_address\x18\x02 \x01(\tR\rwalletAddress\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vtrust_score\x18\x04 \x01(\x05R\n" +
+	"trustScore\x12\x1f\n" +
+	"\vproposed_by\x18\x05 \x01(\tR\n" +
+	"proposedBy\x12/\n" +
+	"\tapprovals\x18\x06 \x03(\v2\x11.auth.v1.ApprovalR\tapprovals\x12\x1c\n" +
+	"\tthreshold\x18\a \x01(\x05R\tthreshold\x12\x14\n" +
+	"\x05state\x18\b \x01(\tR\x05state\x12\x17\n" +
+	"\atx_hash\x18\t \x01(\tR\x06txHash\x12\x14\n" +
+	"\x05error\x18\n" +
+	" \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\f \x01(\x03R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\x03R\tupdatedAt\"\x81\x01\n" +
+	"\x16CreateChallengeRequest\x12%\n" +
+	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\x12\x16\n" +
+	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x10\n" +
+	"\x03uri\x18\x03 \x01(\tR\x03uri\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\"\xdc\x01\n" +
+	"\x17CreateChallengeResponse\x12\x14\n" +
+	"\x05nonce\x18\x01 \x01(\tR\x05nonce\x12%\n" +
+	"\x0ewallet_address\x18\x02 \x01(\tR\rwalletAddress\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x1b\n" +
+	"\tissued_at\x18\x04 \x01(\x03R\bissuedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\x12\x16\n" +
+	"\x06domain\x18\x06 \x01(\tR\x06domain\x12\x16\n" +
+	"\x06scopes\x18\a \x03(\tR\x06scopes\"\x8c\x01\n" +
+	"\x13VerifyWalletRequest\x12%\n" +
+	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\tR\tsignature\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x16\n" +
+	"\x06domain\x18\x04 \x01(\tR\x06domain\"k\n" +
+	"\x14VerifyWalletResponse\x12\x1a\n" +
+	"\bverified\x18\x01 \x01(\bR\bverified\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1f\n" +
+	"\vtrust_score\x18\x03 \x01(\x05R\n" +
+	"trustScore\"B\n" +
 	"\x15BatchGetStatusRequest\x12)\n" +
-	"\x10wallet_addresses\x18\x01 \x03(\tR\x0fwalletAddresses\"P\n" +
-	"\x16BatchGetStatusResponse\x126\n" +
-	"\bstatuses\x18\x01 \x03(\v2\x1a.auth.v1.GetStatusResponseR\bstatuses2\xb5\x02\n" +
+	"\x10wallet_addresses\x18\x01 \x03(\tR\x0fwalletAddresses\"\x8b\x01\n" +
+	"\x16BatchGetStatusResponse\x12:\n" +
+	"\bstatuses\x18\x01 \x03(\v2\x1a.auth.v1.GetStatusResponseB\x02\x18\x01R\bstatuses\x125\n" +
+	"\aresults\x18\x02 \x03(\v2\x1b.auth.v1.WalletStatusResultR\aresults\"\xa4\x01\n" +
+	"\x12WalletStatusResult\x12%\n" +
+	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\x122\n" +
+	"\x06status\x18\x02 \x01(\v2\x1a.auth.v1.GetStatusResponseR\x06status\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\"6\n" +
+	"\x1bGetTransactionStatusRequest\x12\x17\n" +
+	"\atx_hash\x18\x01 \x01(\tR\x06txHash\"\x85\x02\n" +
+	"\x1cGetTransactionStatusResponse\x12\x17\n" +
+	"\atx_hash\x18\x01 \x01(\tR\x06txHash\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\x12%\n" +
+	"\x0ewallet_address\x18\x03 \x01(\tR\rwalletAddress\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x1f\n" +
+	"\vtrust_score\x18\x05 \x01(\x05R\n" +
+	"trustScore\x12!\n" +
+	"\fsubmitted_at\x18\x06 \x01(\x03R\vsubmittedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\x03R\tupdatedAt\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\"\xdd\x02\n" +
+	"\aSession\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12%\n" +
+	"\x0ewallet_address\x18\x02 \x01(\tR\rwalletAddress\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\x03R\tcreatedAt\x12#\n" +
+	"\rlast_activity\x18\x06 \x01(\x03R\flastActivity\x12\x1d\n" +
+	"\n" +
+	"ip_address\x18\a \x01(\tR\tipAddress\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\b \x01(\tR\tuserAgent\x12\x16\n" +
+	"\x06handle\x18\t \x01(\tR\x06handle\x12\x18\n" +
+	"\acurrent\x18\n" +
+	" \x01(\bR\acurrent\x12#\n" +
+	"\rrefresh_token\x18\v \x01(\tR\frefreshToken\"\x9f\x01\n" +
+	"\x14CreateSessionRequest\x12%\n" +
+	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\x12\x1c\n" +
+	"\tsignature\x18\x02 \x01(\tR\tsignature\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x16\n" +
+	"\x06domain\x18\x04 \x01(\tR\x06domain\x12\x10\n" +
+	"\x03ttl\x18\x05 \x01(\x05R\x03ttl\"w\n" +
+	"\x15CreateSessionResponse\x12*\n" +
+	"\asession\x18\x01 \x01(\v2\x10.auth.v1.SessionR\asession\x122\n" +
+	"\x06status\x18\x02 \x01(\v2\x1a.auth.v1.GetStatusResponseR\x06status\"H\n" +
+	"\x15RefreshSessionRequest\x12#\n" +
+	"\rrefresh_token\x18\x03 \x01(\tR\frefreshTokenJ\x04\b\x01\x10\x02J\x04\b\x02\x10\x03\".\n" +
+	"\x14DeleteSessionRequest\x12\x16\n" +
+	"\x06handle\x18\x01 \x01(\tR\x06handle\"1\n" +
+	"\x15DeleteSessionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"A\n" +
+	"\x18GetActiveSessionsRequest\x12%\n" +
+	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\"I\n" +
+	"\x19GetActiveSessionsResponse\x12,\n" +
+	"\bsessions\x18\x01 \x03(\v2\x10.auth.v1.SessionR\bsessions\">\n" +
+	"\x15EndAllSessionsRequest\x12%\n" +
+	"\x0ewallet_address\x18\x01 \x01(\tR\rwalletAddress\"?\n" +
+	"\x16EndAllSessionsResponse\x12%\n" +
+	"\x0esessions_ended\x18\x01 \x01(\x05R\rsessionsEnded\"*\n" +
+	"\x12RevokeTokenRequest\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"/\n" +
+	"\x13RevokeTokenResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess2\xaa\t\n" +
 	"\vAuthService\x12B\n" +
 	"\tGetStatus\x12\x19.auth.v1.GetStatusRequest\x1a\x1a.auth.v1.GetStatusResponse\x12B\n" +
-	"\tSetStatus\x12\x19.auth.v1.SetStatusRequest\x1a\x1a.auth.v1.SetStatusResponse\x12K\n" +
+	"\tSetStatus\x12\x19.auth.v1.SetStatusRequest\x1a\x1a.auth.v1.SetStatusResponse\x12E\n" +
+	"\rProposeStatus\x12\x19.auth.v1.SetStatusRequest\x1a\x19.auth.v1.ProposalResponse\x12M\n" +
+	"\x0fApproveProposal\x12\x1f.auth.v1.ApproveProposalRequest\x1a\x19.auth.v1.ProposalResponse\x12E\n" +
+	"\vGetProposal\x12\x1b.auth.v1.GetProposalRequest\x1a\x19.auth.v1.ProposalResponse\x12T\n" +
+	"\x0fCreateChallenge\x12\x1f.auth.v1.CreateChallengeRequest\x1a .auth.v1.CreateChallengeResponse\x12K\n" +
 	"\fVerifyWallet\x12\x1c.auth.v1.VerifyWalletRequest\x1a\x1d.auth.v1.VerifyWalletResponse\x12Q\n" +
-	"\x0eBatchGetStatus\x12\x1e.auth.v1.BatchGetStatusRequest\x1a\x1f.auth.v1.BatchGetStatusResponseB.Z,qubic-microauth/api/proto/gen/auth/v1;authv1b\x06proto3"
+	"\x0eBatchGetStatus\x12\x1e.auth.v1.BatchGetStatusRequest\x1a\x1f.auth.v1.BatchGetStatusResponse\x12c\n" +
+	"\x14GetTransactionStatus\x12$.auth.v1.GetTransactionStatusRequest\x1a%.auth.v1.GetTransactionStatusResponse\x12N\n" +
+	"\rCreateSession\x12\x1d.auth.v1.CreateSessionRequest\x1a\x1e.auth.v1.CreateSessionResponse\x12B\n" +
+	"\x0eRefreshSession\x12\x1e.auth.v1.RefreshSessionRequest\x1a\x10.auth.v1.Session\x12N\n" +
+	"\rDeleteSession\x12\x1d.auth.v1.DeleteSessionRequest\x1a\x1e.auth.v1.DeleteSessionResponse\x12Z\n" +
+	"\x11GetActiveSessions\x12!.auth.v1.GetActiveSessionsRequest\x1a\".auth.v1.GetActiveSessionsResponse\x12Q\n" +
+	"\x0eEndAllSessions\x12\x1e.auth.v1.EndAllSessionsRequest\x1a\x1f.auth.v1.EndAllSessionsResponse\x12H\n" +
+	"\vRevokeToken\x12\x1b.auth.v1.RevokeTokenRequest\x1a\x1c.auth.v1.RevokeTokenResponseB.Z,qubic-microauth/api/proto/gen/auth/v1;authv1b\x06proto3"
 
 var (
 	file_api_proto_auth_proto_rawDescOnce sync.Once
@@ -524,32 +2048,81 @@ func file_api_proto_auth_proto_rawDescGZIP() []byte {
 	return file_api_proto_auth_proto_rawDescData
 }
 
-var file_api_proto_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_proto_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
 var file_api_proto_auth_proto_goTypes = []any{
-	(*GetStatusRequest)(nil),       // 0: auth.v1.GetStatusRequest
-	(*GetStatusResponse)(nil),      // 1: auth.v1.GetStatusResponse
-	(*SetStatusRequest)(nil),       // 2: auth.v1.SetStatusRequest
-	(*SetStatusResponse)(nil),      // 3: auth.v1.SetStatusResponse
-	(*VerifyWalletRequest)(nil),    // 4: auth.v1.VerifyWalletRequest
-	(*VerifyWalletResponse)(nil),   // 5: auth.v1.VerifyWalletResponse
-	(*BatchGetStatusRequest)(nil),  // 6: auth.v1.BatchGetStatusRequest
-	(*BatchGetStatusResponse)(nil), // 7: auth.v1.BatchGetStatusResponse
+	(*GetStatusRequest)(nil),             // 0: auth.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),            // 1: auth.v1.GetStatusResponse
+	(*SetStatusRequest)(nil),             // 2: auth.v1.SetStatusRequest
+	(*SetStatusResponse)(nil),            // 3: auth.v1.SetStatusResponse
+	(*ApproveProposalRequest)(nil),       // 4: auth.v1.ApproveProposalRequest
+	(*GetProposalRequest)(nil),           // 5: auth.v1.GetProposalRequest
+	(*Approval)(nil),                     // 6: auth.v1.Approval
+	(*ProposalResponse)(nil),             // 7: auth.v1.ProposalResponse
+	(*CreateChallengeRequest)(nil),       // 8: auth.v1.CreateChallengeRequest
+	(*CreateChallengeResponse)(nil),      // 9: auth.v1.CreateChallengeResponse
+	(*VerifyWalletRequest)(nil),          // 10: auth.v1.VerifyWalletRequest
+	(*VerifyWalletResponse)(nil),         // 11: auth.v1.VerifyWalletResponse
+	(*BatchGetStatusRequest)(nil),        // 12: auth.v1.BatchGetStatusRequest
+	(*BatchGetStatusResponse)(nil),       // 13: auth.v1.BatchGetStatusResponse
+	(*WalletStatusResult)(nil),           // 14: auth.v1.WalletStatusResult
+	(*GetTransactionStatusRequest)(nil),  // 15: auth.v1.GetTransactionStatusRequest
+	(*GetTransactionStatusResponse)(nil), // 16: auth.v1.GetTransactionStatusResponse
+	(*Session)(nil),                      // 17: auth.v1.Session
+	(*CreateSessionRequest)(nil),         // 18: auth.v1.CreateSessionRequest
+	(*CreateSessionResponse)(nil),        // 19: auth.v1.CreateSessionResponse
+	(*RefreshSessionRequest)(nil),        // 20: auth.v1.RefreshSessionRequest
+	(*DeleteSessionRequest)(nil),         // 21: auth.v1.DeleteSessionRequest
+	(*DeleteSessionResponse)(nil),        // 22: auth.v1.DeleteSessionResponse
+	(*GetActiveSessionsRequest)(nil),     // 23: auth.v1.GetActiveSessionsRequest
+	(*GetActiveSessionsResponse)(nil),    // 24: auth.v1.GetActiveSessionsResponse
+	(*EndAllSessionsRequest)(nil),        // 25: auth.v1.EndAllSessionsRequest
+	(*EndAllSessionsResponse)(nil),       // 26: auth.v1.EndAllSessionsResponse
+	(*RevokeTokenRequest)(nil),           // 27: auth.v1.RevokeTokenRequest
+	(*RevokeTokenResponse)(nil),          // 28: auth.v1.RevokeTokenResponse
 }
 var file_api_proto_auth_proto_depIdxs = []int32{
-	1, // 0: auth.v1.BatchGetStatusResponse.statuses:type_name -> auth.v1.GetStatusResponse
-	0, // 1: auth.v1.AuthService.GetStatus:input_type -> auth.v1.GetStatusRequest
-	2, // 2: auth.v1.AuthService.SetStatus:input_type -> auth.v1.SetStatusRequest
-	4, // 3: auth.v1.AuthService.VerifyWallet:input_type -> auth.v1.VerifyWalletRequest
-	6, // 4: auth.v1.AuthService.BatchGetStatus:input_type -> auth.v1.BatchGetStatusRequest
-	1, // 5: auth.v1.AuthService.GetStatus:output_type -> auth.v1.GetStatusResponse
-	3, // 6: auth.v1.AuthService.SetStatus:output_type -> auth.v1.SetStatusResponse
-	5, // 7: auth.v1.AuthService.VerifyWallet:output_type -> auth.v1.VerifyWalletResponse
-	7, // 8: auth.v1.AuthService.BatchGetStatus:output_type -> auth.v1.BatchGetStatusResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	6,  // 0: auth.v1.ProposalResponse.approvals:type_name -> auth.v1.Approval
+	1,  // 1: auth.v1.BatchGetStatusResponse.statuses:type_name -> auth.v1.GetStatusResponse
+	14, // 2: auth.v1.BatchGetStatusResponse.results:type_name -> auth.v1.WalletStatusResult
+	1,  // 3: auth.v1.WalletStatusResult.status:type_name -> auth.v1.GetStatusResponse
+	17, // 4: auth.v1.CreateSessionResponse.session:type_name -> auth.v1.Session
+	1,  // 5: auth.v1.CreateSessionResponse.status:type_name -> auth.v1.GetStatusResponse
+	17, // 6: auth.v1.GetActiveSessionsResponse.sessions:type_name -> auth.v1.Session
+	0,  // 7: auth.v1.AuthService.GetStatus:input_type -> auth.v1.GetStatusRequest
+	2,  // 8: auth.v1.AuthService.SetStatus:input_type -> auth.v1.SetStatusRequest
+	2,  // 9: auth.v1.AuthService.ProposeStatus:input_type -> auth.v1.SetStatusRequest
+	4,  // 10: auth.v1.AuthService.ApproveProposal:input_type -> auth.v1.ApproveProposalRequest
+	5,  // 11: auth.v1.AuthService.GetProposal:input_type -> auth.v1.GetProposalRequest
+	8,  // 12: auth.v1.AuthService.CreateChallenge:input_type -> auth.v1.CreateChallengeRequest
+	10, // 13: auth.v1.AuthService.VerifyWallet:input_type -> auth.v1.VerifyWalletRequest
+	12, // 14: auth.v1.AuthService.BatchGetStatus:input_type -> auth.v1.BatchGetStatusRequest
+	15, // 15: auth.v1.AuthService.GetTransactionStatus:input_type -> auth.v1.GetTransactionStatusRequest
+	18, // 16: auth.v1.AuthService.CreateSession:input_type -> auth.v1.CreateSessionRequest
+	20, // 17: auth.v1.AuthService.RefreshSession:input_type -> auth.v1.RefreshSessionRequest
+	21, // 18: auth.v1.AuthService.DeleteSession:input_type -> auth.v1.DeleteSessionRequest
+	23, // 19: auth.v1.AuthService.GetActiveSessions:input_type -> auth.v1.GetActiveSessionsRequest
+	25, // 20: auth.v1.AuthService.EndAllSessions:input_type -> auth.v1.EndAllSessionsRequest
+	27, // 21: auth.v1.AuthService.RevokeToken:input_type -> auth.v1.RevokeTokenRequest
+	1,  // 22: auth.v1.AuthService.GetStatus:output_type -> auth.v1.GetStatusResponse
+	3,  // 23: auth.v1.AuthService.SetStatus:output_type -> auth.v1.SetStatusResponse
+	7,  // 24: auth.v1.AuthService.ProposeStatus:output_type -> auth.v1.ProposalResponse
+	7,  // 25: auth.v1.AuthService.ApproveProposal:output_type -> auth.v1.ProposalResponse
+	7,  // 26: auth.v1.AuthService.GetProposal:output_type -> auth.v1.ProposalResponse
+	9,  // 27: auth.v1.AuthService.CreateChallenge:output_type -> auth.v1.CreateChallengeResponse
+	11, // 28: auth.v1.AuthService.VerifyWallet:output_type -> auth.v1.VerifyWalletResponse
+	13, // 29: auth.v1.AuthService.BatchGetStatus:output_type -> auth.v1.BatchGetStatusResponse
+	16, // 30: auth.v1.AuthService.GetTransactionStatus:output_type -> auth.v1.GetTransactionStatusResponse
+	19, // 31: auth.v1.AuthService.CreateSession:output_type -> auth.v1.CreateSessionResponse
+	17, // 32: auth.v1.AuthService.RefreshSession:output_type -> auth.v1.Session
+	22, // 33: auth.v1.AuthService.DeleteSession:output_type -> auth.v1.DeleteSessionResponse
+	24, // 34: auth.v1.AuthService.GetActiveSessions:output_type -> auth.v1.GetActiveSessionsResponse
+	26, // 35: auth.v1.AuthService.EndAllSessions:output_type -> auth.v1.EndAllSessionsResponse
+	28, // 36: auth.v1.AuthService.RevokeToken:output_type -> auth.v1.RevokeTokenResponse
+	22, // [22:37] is the sub-list for method output_type
+	7,  // [7:22] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_auth_proto_init() }
@@ -563,7 +2136,7 @@ func file_api_proto_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_auth_proto_rawDesc), len(file_api_proto_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   29,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
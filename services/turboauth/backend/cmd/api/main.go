@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -16,17 +18,30 @@ import (
 	pb "turboauth/api/proto/api/proto"
 	grpcAdapter "turboauth/internal/adapters/primary/grpc"
 	httpAdapter "turboauth/internal/adapters/primary/http"
+	"turboauth/internal/adapters/secondary/accessstats"
+	"turboauth/internal/adapters/secondary/noncestore"
+	"turboauth/internal/adapters/secondary/proposalstore"
 	"turboauth/internal/adapters/secondary/qubic"
+	"turboauth/internal/adapters/secondary/refreshstore"
+	"turboauth/internal/adapters/secondary/revocation"
+	"turboauth/internal/adapters/secondary/sessionstore"
+	"turboauth/internal/adapters/secondary/token"
 	"turboauth/internal/adapters/secondary/truststore"
+	"turboauth/internal/adapters/secondary/txstore"
 	"turboauth/internal/adapters/secondary/wallet"
+	"turboauth/internal/adapters/secondary/warmup"
 	"turboauth/internal/domain/auth"
 	"turboauth/pkg/config"
 	"turboauth/pkg/logger"
+	"turboauth/pkg/redisclient"
 )
 
 func main() {
 	// Load configuration
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid configuration")
+	}
 
 	// Initialize logger
 	logger.Init(cfg.LogLevel, cfg.LogFormat)
@@ -38,39 +53,238 @@ func main() {
 		Msg("Starting Qubic MicroAuth")
 
 	// Initialize adapters (secondary/infrastructure)
-	qubicClient := qubic.NewClient(cfg.QubicNodeURL, cfg.QubicContractAddr)
+	qubicClient, err := qubic.NewClient(cfg.QubicNodeURL, cfg.QubicContractAddr, cfg.QubicAdminSeed, qubic.BatchConfig{
+		Concurrency: cfg.QubicBatchConcurrency,
+		CallTimeout: cfg.QubicCallTimeout,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize Qubic client")
+	}
 	walletVerifier := wallet.NewVerifier()
 
-	// Initialize trust store (cache)
-	var trustStore truststore.RedisStore
-	if cfg.UseMemoryCache {
-		log.Info().Msg("Using in-memory cache")
-		memStore := truststore.NewMemoryStore()
-		// Type assertion to satisfy the interface
-		// In production, you might want a composite store (L1 + L2)
-		_ = memStore
+	// Admin identities sign status changes; a typo would lock every admin out
+	for _, admin := range cfg.AdminIdentities {
+		if !walletVerifier.ValidateAddress(admin) {
+			log.Fatal().Str("admin", admin).Msg("Invalid admin identity")
+		}
+	}
+	if len(cfg.AdminIdentities) == 0 {
+		log.Warn().Msg("No admin identities configured, status updates will be rejected")
+	}
+
+	approvalStatuses := make([]auth.AuthStatus, 0, len(cfg.AdminApprovalStatuses))
+	for _, s := range cfg.AdminApprovalStatuses {
+		approvalStatuses = append(approvalStatuses, auth.AuthStatus(strings.ToUpper(s)))
+	}
+	adminPolicy := auth.AdminPolicy{
+		Identities:        cfg.AdminIdentities,
+		MaxRequestAge:     cfg.AdminMaxAge,
+		ApprovalThreshold: cfg.AdminApprovalThreshold,
+		ApprovalStatuses:  approvalStatuses,
+		ProposalTTL:       cfg.ProposalTTL,
+	}
+	// A threshold no set of admins can reach would leave proposals stuck
+	if err := adminPolicy.Validate(); err != nil {
+		log.Fatal().Err(err).Msg("Invalid admin approval threshold")
+	}
+
+	// Redis connection shared by the trust, challenge and proposal stores;
+	// REDIS_URL lists the sentinels or cluster seed nodes in those modes
+	redisOpts := redisclient.Options{
+		Mode:                  cfg.RedisMode,
+		Addrs:                 cfg.RedisAddrs,
+		MasterName:            cfg.RedisMasterName,
+		SentinelPassword:      cfg.RedisSentinelPassword,
+		Username:              cfg.RedisUsername,
+		Password:              cfg.RedisPassword,
+		DB:                    cfg.RedisDB,
+		TLS:                   cfg.RedisTLS,
+		TLSCAFile:             cfg.RedisTLSCAFile,
+		TLSCertFile:           cfg.RedisTLSCertFile,
+		TLSKeyFile:            cfg.RedisTLSKeyFile,
+		TLSServerName:         cfg.RedisTLSServerName,
+		TLSInsecureSkipVerify: cfg.RedisTLSInsecureSkipVerify,
+		KeyPrefix:             cfg.RedisKeyPrefix,
+	}
+	if err := redisOpts.Validate(); err != nil {
+		log.Fatal().Err(err).Msg("Invalid Redis configuration")
+	}
+
+	// Initialize trust store (cache): memory (L1) in front of Redis (L2),
+	// falling back to memory alone while Redis is unavailable
+	l1TTL := cfg.L1CacheTTL
+	if !cfg.UseMemoryCache {
+		l1TTL = 0
+	} else {
+		log.Info().Dur("ttl", l1TTL).Msg("Using in-memory cache")
+	}
+	trustStore := truststore.NewTieredStore(truststore.NewMemoryStore(cfg.MemoryCacheMaxEntries, cfg.MemoryCacheMaxBytes), l1TTL, redisOpts)
+	defer trustStore.Close()
+
+	// Initialize challenge nonce store (shared via Redis when available)
+	var nonceStore auth.NoncePort
+	redisNonceStore, err := noncestore.NewRedisStore(redisOpts)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to connect to Redis, using in-memory challenge store")
+		memNonceStore := noncestore.NewMemoryStore()
+		defer memNonceStore.Stop()
+		nonceStore = memNonceStore
+	} else {
+		nonceStore = redisNonceStore
+		defer redisNonceStore.Close()
+	}
+
+	// Initialize status-change proposal store (shared via Redis when available)
+	var proposalStore auth.ProposalPort
+	redisProposalStore, err := proposalstore.NewRedisStore(redisOpts)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to connect to Redis, using in-memory proposal store")
+		memProposalStore := proposalstore.NewMemoryStore()
+		defer memProposalStore.Stop()
+		proposalStore = memProposalStore
+	} else {
+		proposalStore = redisProposalStore
+		defer redisProposalStore.Close()
 	}
 
-	// Initialize Redis store
-	redisStore, err := truststore.NewRedisStore(cfg.RedisURL, cfg.RedisPassword, cfg.RedisDB)
+	// Initialize status change store (shared via Redis when available)
+	var statusChangeStore auth.StatusChangePort
+	redisStatusChangeStore, err := txstore.NewRedisStore(redisOpts)
 	if err != nil {
-		log.Warn().Err(err).Msg("Failed to connect to Redis, using memory store only")
-		// Fallback to memory store
+		log.Warn().Err(err).Msg("Failed to connect to Redis, using in-memory status change store")
+		memStatusChangeStore := txstore.NewMemoryStore()
+		defer memStatusChangeStore.Stop()
+		statusChangeStore = memStatusChangeStore
 	} else {
-		trustStore = *redisStore
-		log.Info().Msg("Connected to Redis")
+		statusChangeStore = redisStatusChangeStore
+		defer redisStatusChangeStore.Close()
+	}
+
+	// Initialize wallet access statistics (shared via Redis when available)
+	var accessStats auth.AccessStatsPort
+	redisAccessStats, err := accessstats.NewRedisStore(redisOpts)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to connect to Redis, using in-memory access statistics")
+		accessStats = accessstats.NewMemoryStore()
+	} else {
+		accessStats = redisAccessStats
+		defer redisAccessStats.Close()
+	}
+
+	// Initialize session store (shared via Redis when available)
+	var sessionStore auth.SessionPort
+	redisSessionStore, err := sessionstore.NewRedisStore(redisOpts)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to connect to Redis, using in-memory session store")
+		sessionStore = sessionstore.NewMemoryStore()
+	} else {
+		sessionStore = redisSessionStore
+		defer redisSessionStore.Close()
+	}
+
+	// Initialize token revocation list (shared via Redis when available)
+	var revocations auth.RevocationPort
+	redisRevocations, err := revocation.NewRedisStore(redisOpts)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to connect to Redis, using in-memory token revocation list")
+		memRevocations := revocation.NewMemoryStore()
+		defer memRevocations.Stop()
+		revocations = memRevocations
+	} else {
+		revocations = redisRevocations
+		defer redisRevocations.Close()
+	}
+
+	// Initialize refresh token family store (shared via Redis when available)
+	var refreshTokens auth.RefreshTokenPort
+	redisRefreshTokens, err := refreshstore.NewRedisStore(redisOpts)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to connect to Redis, using in-memory refresh token store")
+		memRefreshTokens := refreshstore.NewMemoryStore()
+		defer memRefreshTokens.Stop()
+		refreshTokens = memRefreshTokens
+	} else {
+		refreshTokens = redisRefreshTokens
+		defer redisRefreshTokens.Close()
+	}
+
+	// Initialize session token issuer
+	if len(cfg.JWTKeyFiles) == 0 {
+		log.Warn().Msg("No JWT key files configured, signing session tokens with a temporary key")
+	}
+	tokenIssuer, err := token.NewIssuer(token.Config{
+		Issuer:   cfg.JWTIssuer,
+		Audience: cfg.JWTAudience,
+		KeyFiles: cfg.JWTKeyFiles,
+	}, revocations)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize token issuer")
 	}
 
 	// Initialize domain service (hexagonal core)
 	authService := auth.NewService(
 		qubicClient,
 		walletVerifier,
-		&trustStore,
-		cfg.CacheTTL,
+		trustStore,
+		nonceStore,
+		proposalStore,
+		statusChangeStore,
+		auth.WithCachePolicy(auth.CachePolicy{
+			TTL:           cfg.CacheTTL,
+			HardTTL:       cfg.CacheHardTTL,
+			NegativeTTL:   cfg.NegativeCacheTTL,
+			LookupTimeout: cfg.LookupTimeout,
+		}),
+		auth.WithSignInPolicy(auth.SignInPolicy{
+			AllowedDomains: cfg.AllowedDomains,
+			ChainID:        cfg.ChainID,
+			ChallengeTTL:   cfg.ChallengeTTL,
+		}),
+		auth.WithAdminPolicy(adminPolicy),
+		auth.WithAccessStats(accessStats),
+		auth.WithSessionPort(sessionStore),
+		auth.WithSessionPolicy(auth.SessionPolicy{
+			DefaultTTL: cfg.SessionDefaultTTL,
+			MaxTTL:     cfg.SessionMaxTTL,
+		}),
+		auth.WithTokenPort(tokenIssuer),
+		auth.WithRevocationPort(revocations),
+		auth.WithRefreshTokenPort(refreshTokens),
 	)
+	defer authService.Stop()
+
+	// Remove expired sessions in the background until shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	authService.StartSessionCleanup(ctx, cfg.SessionCleanupInterval)
+
+	// Prefetch hot wallets; the instance reports ready once this is done
+	var warmupSources []auth.WarmupSourcePort
+	for _, source := range cfg.WarmupSources {
+		switch strings.ToLower(source) {
+		case "file":
+			if cfg.WarmupFile == "" {
+				log.Fatal().Msg("WARMUP_FILE must be set to warm up from a file")
+			}
+			warmupSources = append(warmupSources, warmup.NewFileSource(cfg.WarmupFile))
+		case "keyspace":
+			warmupSources = append(warmupSources, trustStore)
+		case "stats":
+			warmupSources = append(warmupSources, accessStats)
+		default:
+			log.Fatal().Str("source", source).Msg("Unknown cache warm-up source")
+		}
+	}
+	warmer := auth.NewWarmer(authService, auth.WarmupPolicy{
+		MaxWallets:  cfg.WarmupMaxWallets,
+		BatchSize:   cfg.WarmupBatchSize,
+		Concurrency: cfg.WarmupConcurrency,
+		Timeout:     cfg.WarmupTimeout,
+	}, warmupSources...)
+	warmer.Start()
 
 	// Start HTTP server (Fiber)
-	go startHTTPServer(cfg, authService)
+	go startHTTPServer(cfg, authService, warmer)
 
 	// Start gRPC server
 	go startGRPCServer(cfg, authService)
@@ -84,7 +298,7 @@ func main() {
 	// TODO: Implement graceful shutdown for both servers
 }
 
-func startHTTPServer(cfg *config.Config, svc *auth.Service) {
+func startHTTPServer(cfg *config.Config, svc *auth.Service, warmer *auth.Warmer) {
 	app := fiber.New(fiber.Config{
 		Prefork:           false, // Set true for multi-process in production
 		ServerHeader:      "MicroAuth",
@@ -103,7 +317,7 @@ func startHTTPServer(cfg *config.Config, svc *auth.Service) {
 	}))
 
 	// Setup routes
-	handler := httpAdapter.NewHandler(svc)
+	handler := httpAdapter.NewHandler(svc, warmer)
 	httpAdapter.SetupRoutes(app, handler)
 
 	addr := fmt.Sprintf(":%d", cfg.HTTPPort)
@@ -2,9 +2,14 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"net"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
 	pb "turboauth/api/proto/api/proto"
@@ -32,18 +37,13 @@ func (s *Server) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.G
 	walletAuth, err := s.authService.GetStatus(ctx, req.WalletAddress)
 	if err != nil {
 		metrics.GRPCRequestsTotal.WithLabelValues("GetStatus", "error").Inc()
-		return nil, status.Errorf(codes.Internal, "failed to get status: %v", err)
+		return nil, toStatusError(err, "failed to get status")
 	}
 
 	metrics.GRPCRequestsTotal.WithLabelValues("GetStatus", "success").Inc()
 	metrics.GRPCRequestDuration.WithLabelValues("GetStatus").Observe(time.Since(start).Seconds())
 
-	return &pb.GetStatusResponse{
-		Status:          string(walletAuth.Status),
-		TrustScore:      int32(walletAuth.TrustScore),
-		UpdatedAt:       walletAuth.UpdatedAt.Unix(),
-		ContractAddress: walletAuth.ContractAddress,
-	}, nil
+	return toStatusResponse(walletAuth), nil
 }
 
 // SetStatus updates authentication status (admin only)
@@ -54,13 +54,16 @@ func (s *Server) SetStatus(ctx context.Context, req *pb.SetStatusRequest) (*pb.S
 		WalletAddress:  req.WalletAddress,
 		Status:         auth.AuthStatus(req.Status),
 		TrustScore:     int(req.TrustScore),
+		AdminAddress:   req.AdminAddress,
+		Nonce:          req.Nonce,
+		Timestamp:      req.Timestamp,
 		AdminSignature: req.AdminSignature,
 	}
 
 	txHash, err := s.authService.SetStatus(ctx, setReq)
 	if err != nil {
 		metrics.GRPCRequestsTotal.WithLabelValues("SetStatus", "error").Inc()
-		return nil, status.Errorf(codes.Internal, "failed to set status: %v", err)
+		return nil, toStatusError(err, "failed to set status")
 	}
 
 	metrics.GRPCRequestsTotal.WithLabelValues("SetStatus", "success").Inc()
@@ -69,7 +72,98 @@ func (s *Server) SetStatus(ctx context.Context, req *pb.SetStatusRequest) (*pb.S
 	return &pb.SetStatusResponse{
 		Success: true,
 		TxHash:  txHash,
-		Message: "Status updated successfully",
+		Message: "Status update submitted, awaiting confirmation",
+	}, nil
+}
+
+// ProposeStatus proposes a status change that needs several admin approvals
+func (s *Server) ProposeStatus(ctx context.Context, req *pb.SetStatusRequest) (*pb.ProposalResponse, error) {
+	start := time.Now()
+
+	proposal, err := s.authService.ProposeStatus(ctx, &auth.SetStatusRequest{
+		WalletAddress:  req.WalletAddress,
+		Status:         auth.AuthStatus(req.Status),
+		TrustScore:     int(req.TrustScore),
+		AdminAddress:   req.AdminAddress,
+		Nonce:          req.Nonce,
+		Timestamp:      req.Timestamp,
+		AdminSignature: req.AdminSignature,
+	})
+	if err != nil {
+		metrics.GRPCRequestsTotal.WithLabelValues("ProposeStatus", "error").Inc()
+		return nil, toStatusError(err, "failed to propose status")
+	}
+
+	metrics.GRPCRequestsTotal.WithLabelValues("ProposeStatus", "success").Inc()
+	metrics.GRPCRequestDuration.WithLabelValues("ProposeStatus").Observe(time.Since(start).Seconds())
+
+	return toProposalResponse(proposal), nil
+}
+
+// ApproveProposal adds an admin approval to a pending proposal
+func (s *Server) ApproveProposal(ctx context.Context, req *pb.ApproveProposalRequest) (*pb.ProposalResponse, error) {
+	start := time.Now()
+
+	proposal, err := s.authService.ApproveProposal(ctx, &auth.ApproveRequest{
+		ProposalID:     req.ProposalId,
+		AdminAddress:   req.AdminAddress,
+		Nonce:          req.Nonce,
+		Timestamp:      req.Timestamp,
+		AdminSignature: req.AdminSignature,
+	})
+	if err != nil {
+		metrics.GRPCRequestsTotal.WithLabelValues("ApproveProposal", "error").Inc()
+		return nil, toStatusError(err, "failed to approve proposal")
+	}
+
+	metrics.GRPCRequestsTotal.WithLabelValues("ApproveProposal", "success").Inc()
+	metrics.GRPCRequestDuration.WithLabelValues("ApproveProposal").Observe(time.Since(start).Seconds())
+
+	return toProposalResponse(proposal), nil
+}
+
+// GetProposal returns a status-change proposal and its approvals
+func (s *Server) GetProposal(ctx context.Context, req *pb.GetProposalRequest) (*pb.ProposalResponse, error) {
+	start := time.Now()
+
+	proposal, err := s.authService.GetProposal(ctx, req.ProposalId)
+	if err != nil {
+		metrics.GRPCRequestsTotal.WithLabelValues("GetProposal", "error").Inc()
+		return nil, toStatusError(err, "failed to get proposal")
+	}
+
+	metrics.GRPCRequestsTotal.WithLabelValues("GetProposal", "success").Inc()
+	metrics.GRPCRequestDuration.WithLabelValues("GetProposal").Observe(time.Since(start).Seconds())
+
+	return toProposalResponse(proposal), nil
+}
+
+// CreateChallenge issues a single-use challenge message for a wallet to sign
+func (s *Server) CreateChallenge(ctx context.Context, req *pb.CreateChallengeRequest) (*pb.CreateChallengeResponse, error) {
+	start := time.Now()
+
+	challenge, err := s.authService.CreateChallenge(ctx, &auth.ChallengeRequest{
+		WalletAddress: req.WalletAddress,
+		Domain:        req.Domain,
+		URI:           req.Uri,
+		Scopes:        req.Scopes,
+	})
+	if err != nil {
+		metrics.GRPCRequestsTotal.WithLabelValues("CreateChallenge", "error").Inc()
+		return nil, toStatusError(err, "failed to create challenge")
+	}
+
+	metrics.GRPCRequestsTotal.WithLabelValues("CreateChallenge", "success").Inc()
+	metrics.GRPCRequestDuration.WithLabelValues("CreateChallenge").Observe(time.Since(start).Seconds())
+
+	return &pb.CreateChallengeResponse{
+		Nonce:         challenge.Nonce,
+		WalletAddress: challenge.WalletAddress,
+		Domain:        challenge.Domain,
+		Scopes:        challenge.Scopes,
+		Message:       challenge.Message,
+		IssuedAt:      challenge.IssuedAt.Unix(),
+		ExpiresAt:     challenge.ExpiresAt.Unix(),
 	}, nil
 }
 
@@ -81,12 +175,13 @@ func (s *Server) VerifyWallet(ctx context.Context, req *pb.VerifyWalletRequest)
 		WalletAddress: req.WalletAddress,
 		Signature:     req.Signature,
 		Message:       req.Message,
+		Domain:        req.Domain,
 	}
 
 	walletAuth, verified, err := s.authService.VerifyWallet(ctx, verifyReq)
 	if err != nil {
 		metrics.GRPCRequestsTotal.WithLabelValues("VerifyWallet", "error").Inc()
-		return nil, status.Errorf(codes.Internal, "failed to verify wallet: %v", err)
+		return nil, toStatusError(err, "failed to verify wallet")
 	}
 
 	metrics.GRPCRequestsTotal.WithLabelValues("VerifyWallet", "success").Inc()
@@ -103,27 +198,312 @@ func (s *Server) VerifyWallet(ctx context.Context, req *pb.VerifyWalletRequest)
 func (s *Server) BatchGetStatus(ctx context.Context, req *pb.BatchGetStatusRequest) (*pb.BatchGetStatusResponse, error) {
 	start := time.Now()
 
-	statuses, err := s.authService.BatchGetStatus(ctx, req.WalletAddresses)
+	results, err := s.authService.BatchGetStatus(ctx, req.WalletAddresses)
 	if err != nil {
 		metrics.GRPCRequestsTotal.WithLabelValues("BatchGetStatus", "error").Inc()
-		return nil, status.Errorf(codes.Internal, "failed to batch get status: %v", err)
+		return nil, toStatusError(err, "failed to batch get status")
 	}
 
 	metrics.GRPCRequestsTotal.WithLabelValues("BatchGetStatus", "success").Inc()
 	metrics.GRPCRequestDuration.WithLabelValues("BatchGetStatus").Observe(time.Since(start).Seconds())
 
-	// Convert to protobuf responses
-	pbStatuses := make([]*pb.GetStatusResponse, len(statuses))
-	for i, s := range statuses {
-		pbStatuses[i] = &pb.GetStatusResponse{
-			Status:          string(s.Status),
-			TrustScore:      int32(s.TrustScore),
-			UpdatedAt:       s.UpdatedAt.Unix(),
-			ContractAddress: s.ContractAddress,
+	// Convert to protobuf responses; statuses keeps serving older clients
+	// that only understand successful lookups
+	pbResults := make([]*pb.WalletStatusResult, len(results))
+	pbStatuses := make([]*pb.GetStatusResponse, 0, len(results))
+	for i, result := range results {
+		pbResults[i] = &pb.WalletStatusResult{
+			WalletAddress: result.WalletAddress,
 		}
+		if result.Err != nil {
+			pbResults[i].Error = result.Err.Error()
+			pbResults[i].ErrorCode = errorCode(result.Err).String()
+			continue
+		}
+		pbResults[i].Status = toStatusResponse(result.Status)
+		pbStatuses = append(pbStatuses, pbResults[i].Status)
 	}
 
 	return &pb.BatchGetStatusResponse{
 		Statuses: pbStatuses,
+		Results:  pbResults,
 	}, nil
 }
+
+// GetTransactionStatus reports the confirmation state of a status-change transaction
+func (s *Server) GetTransactionStatus(ctx context.Context, req *pb.GetTransactionStatusRequest) (*pb.GetTransactionStatusResponse, error) {
+	start := time.Now()
+
+	change, err := s.authService.GetTransaction(ctx, req.TxHash)
+	if err != nil {
+		metrics.GRPCRequestsTotal.WithLabelValues("GetTransactionStatus", "error").Inc()
+		return nil, toStatusError(err, "failed to get transaction status")
+	}
+
+	metrics.GRPCRequestsTotal.WithLabelValues("GetTransactionStatus", "success").Inc()
+	metrics.GRPCRequestDuration.WithLabelValues("GetTransactionStatus").Observe(time.Since(start).Seconds())
+
+	return &pb.GetTransactionStatusResponse{
+		TxHash:        change.TxHash,
+		State:         string(change.State),
+		WalletAddress: change.WalletAddress,
+		Status:        string(change.Status),
+		TrustScore:    int32(change.TrustScore),
+		SubmittedAt:   change.SubmittedAt.Unix(),
+		UpdatedAt:     change.UpdatedAt.Unix(),
+		Error:         change.Error,
+	}, nil
+}
+
+// CreateSession verifies a wallet signature and opens a session
+func (s *Server) CreateSession(ctx context.Context, req *pb.CreateSessionRequest) (*pb.CreateSessionResponse, error) {
+	start := time.Now()
+
+	ipAddress, userAgent := clientInfo(ctx)
+	resp, err := s.authService.CreateSession(ctx, &auth.SessionRequest{
+		WalletAddress: req.WalletAddress,
+		Signature:     req.Signature,
+		Message:       req.Message,
+		Domain:        req.Domain,
+		TTL:           int(req.Ttl),
+		IPAddress:     ipAddress,
+		UserAgent:     userAgent,
+	})
+	if err != nil {
+		metrics.GRPCRequestsTotal.WithLabelValues("CreateSession", "error").Inc()
+		return nil, toStatusError(err, "failed to create session")
+	}
+
+	metrics.GRPCRequestsTotal.WithLabelValues("CreateSession", "success").Inc()
+	metrics.GRPCRequestDuration.WithLabelValues("CreateSession").Observe(time.Since(start).Seconds())
+
+	return &pb.CreateSessionResponse{
+		Session: toSession(resp.Session),
+		Status:  toStatusResponse(resp.Status),
+	}, nil
+}
+
+// RefreshSession extends a session by its original lifetime
+func (s *Server) RefreshSession(ctx context.Context, req *pb.RefreshSessionRequest) (*pb.Session, error) {
+	start := time.Now()
+
+	ipAddress, userAgent := clientInfo(ctx)
+	session, err := s.authService.RefreshSession(ctx, &auth.RefreshSessionRequest{
+		RefreshToken: req.RefreshToken,
+		IPAddress:    ipAddress,
+		UserAgent:    userAgent,
+	})
+	if err != nil {
+		metrics.GRPCRequestsTotal.WithLabelValues("RefreshSession", "error").Inc()
+		return nil, toStatusError(err, "failed to refresh session")
+	}
+
+	metrics.GRPCRequestsTotal.WithLabelValues("RefreshSession", "success").Inc()
+	metrics.GRPCRequestDuration.WithLabelValues("RefreshSession").Observe(time.Since(start).Seconds())
+
+	return toSession(session), nil
+}
+
+// DeleteSession ends a session of the caller's wallet by handle
+func (s *Server) DeleteSession(ctx context.Context, req *pb.DeleteSessionRequest) (*pb.DeleteSessionResponse, error) {
+	start := time.Now()
+
+	if err := s.authService.DeleteSession(ctx, &auth.DeleteSessionRequest{
+		AccessToken: bearerToken(ctx),
+		Handle:      req.Handle,
+	}); err != nil {
+		metrics.GRPCRequestsTotal.WithLabelValues("DeleteSession", "error").Inc()
+		return nil, toStatusError(err, "failed to delete session")
+	}
+
+	metrics.GRPCRequestsTotal.WithLabelValues("DeleteSession", "success").Inc()
+	metrics.GRPCRequestDuration.WithLabelValues("DeleteSession").Observe(time.Since(start).Seconds())
+
+	return &pb.DeleteSessionResponse{Success: true}, nil
+}
+
+// GetActiveSessions lists the active sessions of a wallet
+func (s *Server) GetActiveSessions(ctx context.Context, req *pb.GetActiveSessionsRequest) (*pb.GetActiveSessionsResponse, error) {
+	start := time.Now()
+
+	sessions, err := s.authService.GetActiveSessions(ctx, &auth.ActiveSessionsRequest{
+		WalletAddress: req.WalletAddress,
+		AccessToken:   bearerToken(ctx),
+	})
+	if err != nil {
+		metrics.GRPCRequestsTotal.WithLabelValues("GetActiveSessions", "error").Inc()
+		return nil, toStatusError(err, "failed to get active sessions")
+	}
+
+	metrics.GRPCRequestsTotal.WithLabelValues("GetActiveSessions", "success").Inc()
+	metrics.GRPCRequestDuration.WithLabelValues("GetActiveSessions").Observe(time.Since(start).Seconds())
+
+	pbSessions := make([]*pb.Session, len(sessions))
+	for i, session := range sessions {
+		pbSessions[i] = toSession(session)
+	}
+
+	return &pb.GetActiveSessionsResponse{Sessions: pbSessions}, nil
+}
+
+// EndAllSessions revokes every token of a wallet and ends its sessions
+func (s *Server) EndAllSessions(ctx context.Context, req *pb.EndAllSessionsRequest) (*pb.EndAllSessionsResponse, error) {
+	start := time.Now()
+
+	ended, err := s.authService.EndAllSessions(ctx, &auth.ActiveSessionsRequest{
+		WalletAddress: req.WalletAddress,
+		AccessToken:   bearerToken(ctx),
+	})
+	if err != nil {
+		metrics.GRPCRequestsTotal.WithLabelValues("EndAllSessions", "error").Inc()
+		return nil, toStatusError(err, "failed to end sessions")
+	}
+
+	metrics.GRPCRequestsTotal.WithLabelValues("EndAllSessions", "success").Inc()
+	metrics.GRPCRequestDuration.WithLabelValues("EndAllSessions").Observe(time.Since(start).Seconds())
+
+	return &pb.EndAllSessionsResponse{SessionsEnded: int32(ended)}, nil
+}
+
+// RevokeToken revokes a single session token
+func (s *Server) RevokeToken(ctx context.Context, req *pb.RevokeTokenRequest) (*pb.RevokeTokenResponse, error) {
+	start := time.Now()
+
+	if err := s.authService.RevokeToken(ctx, &auth.RevokeTokenRequest{Token: req.Token}); err != nil {
+		metrics.GRPCRequestsTotal.WithLabelValues("RevokeToken", "error").Inc()
+		return nil, toStatusError(err, "failed to revoke token")
+	}
+
+	metrics.GRPCRequestsTotal.WithLabelValues("RevokeToken", "success").Inc()
+	metrics.GRPCRequestDuration.WithLabelValues("RevokeToken").Observe(time.Since(start).Seconds())
+
+	return &pb.RevokeTokenResponse{Success: true}, nil
+}
+
+// toStatusResponse converts a wallet status to its protobuf form
+func toStatusResponse(walletAuth *auth.WalletAuth) *pb.GetStatusResponse {
+	return &pb.GetStatusResponse{
+		WalletAddress:   walletAuth.WalletAddress,
+		Status:          string(walletAuth.Status),
+		TrustScore:      int32(walletAuth.TrustScore),
+		UpdatedAt:       walletAuth.UpdatedAt.Unix(),
+		ContractAddress: walletAuth.ContractAddress,
+		Stale:           walletAuth.Stale,
+	}
+}
+
+// toProposalResponse converts a proposal to its protobuf form
+func toProposalResponse(proposal *auth.Proposal) *pb.ProposalResponse {
+	approvals := make([]*pb.Approval, len(proposal.Approvals))
+	for i, a := range proposal.Approvals {
+		approvals[i] = &pb.Approval{
+			AdminAddress: a.AdminAddress,
+			ApprovedAt:   a.ApprovedAt.Unix(),
+		}
+	}
+
+	return &pb.ProposalResponse{
+		ProposalId:    proposal.ProposalID,
+		WalletAddress: proposal.WalletAddress,
+		Status:        string(proposal.Status),
+		TrustScore:    int32(proposal.TrustScore),
+		ProposedBy:    proposal.ProposedBy,
+		Approvals:     approvals,
+		Threshold:     int32(proposal.Threshold),
+		State:         string(proposal.State),
+		TxHash:        proposal.TxHash,
+		Error:         proposal.Error,
+		CreatedAt:     proposal.CreatedAt.Unix(),
+		ExpiresAt:     proposal.ExpiresAt.Unix(),
+		UpdatedAt:     proposal.UpdatedAt.Unix(),
+	}
+}
+
+// toSession converts a session to its protobuf form
+func toSession(session *auth.Session) *pb.Session {
+	return &pb.Session{
+		SessionId:     session.SessionID,
+		WalletAddress: session.WalletAddress,
+		Token:         session.Token,
+		ExpiresAt:     session.ExpiresAt.Unix(),
+		CreatedAt:     session.CreatedAt.Unix(),
+		LastActivity:  session.LastActivity.Unix(),
+		IpAddress:     session.IPAddress,
+		UserAgent:     session.UserAgent,
+		RefreshToken:  session.RefreshToken,
+		Handle:        session.Handle,
+		Current:       session.Current,
+	}
+}
+
+// clientInfo returns the address and user agent of the calling client
+func clientInfo(ctx context.Context) (ipAddress, userAgent string) {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		ipAddress = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ipAddress); err == nil {
+			ipAddress = host
+		}
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			userAgent = values[0]
+		}
+	}
+	return ipAddress, userAgent
+}
+
+// bearerToken returns the access token sent as "authorization: Bearer <token>"
+// metadata, or an empty string
+func bearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		if scheme, token, found := strings.Cut(value, " "); found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+// toStatusError maps domain errors to gRPC status errors
+func toStatusError(err error, msg string) error {
+	return status.Errorf(errorCode(err), "%s: %v", msg, err)
+}
+
+// errorCode maps domain errors to gRPC codes
+func errorCode(err error) codes.Code {
+	switch {
+	case errors.Is(err, auth.ErrInvalidAddress),
+		errors.Is(err, auth.ErrInvalidMessage),
+		errors.Is(err, auth.ErrInvalidStatus),
+		errors.Is(err, auth.ErrInvalidTrustScore),
+		errors.Is(err, auth.ErrBatchTooLarge):
+		return codes.InvalidArgument
+	case errors.Is(err, auth.ErrDomainNotAllowed),
+		errors.Is(err, auth.ErrUnauthorized),
+		errors.Is(err, auth.ErrWalletBlocked):
+		return codes.PermissionDenied
+	case errors.Is(err, auth.ErrInvalidSignature),
+		errors.Is(err, auth.ErrChallengeNotFound),
+		errors.Is(err, auth.ErrChallengeExpired),
+		errors.Is(err, auth.ErrSessionExpired),
+		errors.Is(err, auth.ErrInvalidToken),
+		errors.Is(err, auth.ErrRefreshTokenReused):
+		return codes.Unauthenticated
+	case errors.Is(err, auth.ErrWalletNotFound),
+		errors.Is(err, auth.ErrTxNotFound),
+		errors.Is(err, auth.ErrProposalNotFound),
+		errors.Is(err, auth.ErrSessionNotFound):
+		return codes.NotFound
+	case errors.Is(err, auth.ErrRateLimitExceeded):
+		return codes.ResourceExhausted
+	case errors.Is(err, auth.ErrProposalClosed):
+		return codes.FailedPrecondition
+	case errors.Is(err, auth.ErrAlreadyApproved):
+		return codes.AlreadyExists
+	default:
+		return codes.Internal
+	}
+}
@@ -1,6 +1,9 @@
 package http
 
 import (
+	"errors"
+	"strconv"
+	"strings"
 	"time"
 
 	"turboauth/internal/domain/auth"
@@ -12,12 +15,14 @@ import (
 // Handler handles HTTP requests for authentication
 type Handler struct {
 	authService *auth.Service
+	warmer      *auth.Warmer
 }
 
 // NewHandler creates a new HTTP handler
-func NewHandler(authService *auth.Service) *Handler {
+func NewHandler(authService *auth.Service, warmer *auth.Warmer) *Handler {
 	return &Handler{
 		authService: authService,
+		warmer:      warmer,
 	}
 }
 
@@ -28,8 +33,9 @@ func (h *Handler) GetStatus(c *fiber.Ctx) error {
 
 	status, err := h.authService.GetStatus(c.Context(), walletAddress)
 	if err != nil {
-		metrics.HTTPRequestsTotal.WithLabelValues("GET", "/status", "500").Inc()
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("GET", "/status", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -40,6 +46,15 @@ func (h *Handler) GetStatus(c *fiber.Ctx) error {
 	return c.JSON(status)
 }
 
+// batchStatusEntry is the outcome for one wallet of a batch status request
+// Code is the HTTP status a single lookup of the wallet would have returned.
+type batchStatusEntry struct {
+	WalletAddress string           `json:"wallet_address"`
+	Status        *auth.WalletAuth `json:"status,omitempty"`
+	Error         string           `json:"error,omitempty"`
+	Code          int              `json:"code,omitempty"`
+}
+
 // BatchGetStatus handles POST /api/v1/status/batch
 func (h *Handler) BatchGetStatus(c *fiber.Ctx) error {
 	start := time.Now()
@@ -55,10 +70,11 @@ func (h *Handler) BatchGetStatus(c *fiber.Ctx) error {
 		})
 	}
 
-	statuses, err := h.authService.BatchGetStatus(c.Context(), req.WalletAddresses)
+	results, err := h.authService.BatchGetStatus(c.Context(), req.WalletAddresses)
 	if err != nil {
-		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/status/batch", "500").Inc()
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/status/batch", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -66,7 +82,25 @@ func (h *Handler) BatchGetStatus(c *fiber.Ctx) error {
 	metrics.HTTPRequestsTotal.WithLabelValues("POST", "/status/batch", "200").Inc()
 	metrics.HTTPRequestDuration.WithLabelValues("POST", "/status/batch").Observe(time.Since(start).Seconds())
 
+	// One entry per distinct wallet in request order; statuses keeps serving
+	// older clients that only understand successful lookups
+	entries := make([]batchStatusEntry, len(results))
+	statuses := make([]*auth.WalletAuth, 0, len(results))
+	for i, result := range results {
+		entries[i] = batchStatusEntry{
+			WalletAddress: result.WalletAddress,
+			Status:        result.Status,
+		}
+		if result.Err != nil {
+			entries[i].Error = result.Err.Error()
+			entries[i].Code = errorStatus(result.Err)
+			continue
+		}
+		statuses = append(statuses, result.Status)
+	}
+
 	return c.JSON(fiber.Map{
+		"results":  entries,
 		"statuses": statuses,
 	})
 }
@@ -85,8 +119,9 @@ func (h *Handler) SetStatus(c *fiber.Ctx) error {
 
 	txHash, err := h.authService.SetStatus(c.Context(), &req)
 	if err != nil {
-		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/status", "500").Inc()
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/status", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -97,9 +132,131 @@ func (h *Handler) SetStatus(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{
 		"success": true,
 		"tx_hash": txHash,
+		"state":   auth.TxPending,
 	})
 }
 
+// ProposeStatus handles POST /api/v1/proposals
+func (h *Handler) ProposeStatus(c *fiber.Ctx) error {
+	start := time.Now()
+
+	var req auth.SetStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/proposals", "400").Inc()
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	proposal, err := h.authService.ProposeStatus(c.Context(), &req)
+	if err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/proposals", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("POST", "/proposals", "200").Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("POST", "/proposals").Observe(time.Since(start).Seconds())
+
+	return c.JSON(proposal)
+}
+
+// ApproveProposal handles POST /api/v1/proposals/:id/approve
+func (h *Handler) ApproveProposal(c *fiber.Ctx) error {
+	start := time.Now()
+
+	var req auth.ApproveRequest
+	if err := c.BodyParser(&req); err != nil {
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/proposals/approve", "400").Inc()
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	req.ProposalID = c.Params("id")
+
+	proposal, err := h.authService.ApproveProposal(c.Context(), &req)
+	if err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/proposals/approve", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("POST", "/proposals/approve", "200").Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("POST", "/proposals/approve").Observe(time.Since(start).Seconds())
+
+	return c.JSON(proposal)
+}
+
+// GetProposal handles GET /api/v1/proposals/:id
+func (h *Handler) GetProposal(c *fiber.Ctx) error {
+	start := time.Now()
+
+	proposal, err := h.authService.GetProposal(c.Context(), c.Params("id"))
+	if err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("GET", "/proposals", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("GET", "/proposals", "200").Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("GET", "/proposals").Observe(time.Since(start).Seconds())
+
+	return c.JSON(proposal)
+}
+
+// GetTransaction handles GET /api/v1/tx/:hash
+func (h *Handler) GetTransaction(c *fiber.Ctx) error {
+	start := time.Now()
+	txHash := c.Params("hash")
+
+	change, err := h.authService.GetTransaction(c.Context(), txHash)
+	if err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("GET", "/tx", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("GET", "/tx", "200").Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("GET", "/tx").Observe(time.Since(start).Seconds())
+
+	return c.JSON(change)
+}
+
+// CreateChallenge handles POST /api/v1/challenge
+func (h *Handler) CreateChallenge(c *fiber.Ctx) error {
+	start := time.Now()
+
+	var req auth.ChallengeRequest
+	if err := c.BodyParser(&req); err != nil {
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/challenge", "400").Inc()
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	challenge, err := h.authService.CreateChallenge(c.Context(), &req)
+	if err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/challenge", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("POST", "/challenge", "200").Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("POST", "/challenge").Observe(time.Since(start).Seconds())
+
+	return c.JSON(challenge)
+}
+
 // VerifyWallet handles POST /api/v1/verify
 func (h *Handler) VerifyWallet(c *fiber.Ctx) error {
 	start := time.Now()
@@ -114,8 +271,9 @@ func (h *Handler) VerifyWallet(c *fiber.Ctx) error {
 
 	status, verified, err := h.authService.VerifyWallet(c.Context(), &req)
 	if err != nil {
-		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/verify", "500").Inc()
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/verify", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
@@ -130,6 +288,176 @@ func (h *Handler) VerifyWallet(c *fiber.Ctx) error {
 	})
 }
 
+// CreateSession handles POST /api/v1/sessions
+func (h *Handler) CreateSession(c *fiber.Ctx) error {
+	start := time.Now()
+
+	var req auth.SessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/sessions", "400").Inc()
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+	resp, err := h.authService.CreateSession(c.Context(), &req)
+	if err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/sessions", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("POST", "/sessions", "201").Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("POST", "/sessions").Observe(time.Since(start).Seconds())
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// RefreshSession handles POST /api/v1/sessions/refresh
+func (h *Handler) RefreshSession(c *fiber.Ctx) error {
+	start := time.Now()
+
+	var req auth.RefreshSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/sessions/refresh", "400").Inc()
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+	req.IPAddress = c.IP()
+	req.UserAgent = c.Get(fiber.HeaderUserAgent)
+
+	session, err := h.authService.RefreshSession(c.Context(), &req)
+	if err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/sessions/refresh", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("POST", "/sessions/refresh", "200").Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("POST", "/sessions/refresh").Observe(time.Since(start).Seconds())
+
+	return c.JSON(session)
+}
+
+// DeleteSession handles DELETE /api/v1/sessions/:id
+// The ID is a session handle as listed by GetActiveSessions; the caller
+// authenticates with an access token of the same wallet in the Authorization
+// header.
+func (h *Handler) DeleteSession(c *fiber.Ctx) error {
+	start := time.Now()
+
+	if err := h.authService.DeleteSession(c.Context(), &auth.DeleteSessionRequest{
+		AccessToken: bearerToken(c),
+		Handle:      c.Params("id"),
+	}); err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("DELETE", "/sessions", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("DELETE", "/sessions", "204").Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("DELETE", "/sessions").Observe(time.Since(start).Seconds())
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetActiveSessions handles GET /api/v1/wallets/:wallet/sessions
+// The caller proves it signed in as the wallet with an access token of it,
+// passed as "Authorization: Bearer <token>".
+func (h *Handler) GetActiveSessions(c *fiber.Ctx) error {
+	start := time.Now()
+
+	sessions, err := h.authService.GetActiveSessions(c.Context(), &auth.ActiveSessionsRequest{
+		WalletAddress: c.Params("wallet"),
+		AccessToken:   bearerToken(c),
+	})
+	if err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("GET", "/wallets/sessions", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("GET", "/wallets/sessions", "200").Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("GET", "/wallets/sessions").Observe(time.Since(start).Seconds())
+
+	return c.JSON(fiber.Map{
+		"sessions": sessions,
+	})
+}
+
+// EndAllSessions handles DELETE /api/v1/wallets/:wallet/sessions
+// The caller proves it signed in as the wallet as for listing its sessions.
+func (h *Handler) EndAllSessions(c *fiber.Ctx) error {
+	start := time.Now()
+
+	ended, err := h.authService.EndAllSessions(c.Context(), &auth.ActiveSessionsRequest{
+		WalletAddress: c.Params("wallet"),
+		AccessToken:   bearerToken(c),
+	})
+	if err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("DELETE", "/wallets/sessions", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("DELETE", "/wallets/sessions", "200").Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("DELETE", "/wallets/sessions").Observe(time.Since(start).Seconds())
+
+	return c.JSON(fiber.Map{
+		"sessions_ended": ended,
+	})
+}
+
+// RevokeToken handles POST /api/v1/tokens/revoke
+func (h *Handler) RevokeToken(c *fiber.Ctx) error {
+	start := time.Now()
+
+	var req auth.RevokeTokenRequest
+	if err := c.BodyParser(&req); err != nil {
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/tokens/revoke", "400").Inc()
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.authService.RevokeToken(c.Context(), &req); err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/tokens/revoke", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("POST", "/tokens/revoke", "204").Inc()
+	metrics.HTTPRequestDuration.WithLabelValues("POST", "/tokens/revoke").Observe(time.Since(start).Seconds())
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// JWKS handles GET /.well-known/jwks.json
+// Downstream services verify session tokens offline with these keys; they
+// should refetch them when a token names an unknown key. Offline verification
+// cannot see revocations, which only stop tokens validated by this service.
+func (h *Handler) JWKS(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+	return c.JSON(fiber.Map{
+		"keys": h.authService.TokenKeys(),
+	})
+}
+
 // HealthCheck handles GET /health
 func (h *Handler) HealthCheck(c *fiber.Ctx) error {
 	if err := h.authService.HealthCheck(c.Context()); err != nil {
@@ -143,3 +471,88 @@ func (h *Handler) HealthCheck(c *fiber.Ctx) error {
 		"status": "healthy",
 	})
 }
+
+// Ready handles GET /ready
+// The instance only reports ready once the startup cache warm-up is done, so
+// load balancers keep traffic away from a cold cache.
+func (h *Handler) Ready(c *fiber.Ctx) error {
+	if !h.warmer.Ready() {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status": "warming up",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"status": "ready",
+	})
+}
+
+// TriggerWarmup handles POST /api/v1/admin/warmup
+func (h *Handler) TriggerWarmup(c *fiber.Ctx) error {
+	var req auth.WarmupRequest
+	if err := c.BodyParser(&req); err != nil {
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/admin/warmup", "400").Inc()
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request body",
+		})
+	}
+
+	if err := h.warmer.Trigger(c.Context(), &req); err != nil {
+		code := errorStatus(err)
+		metrics.HTTPRequestsTotal.WithLabelValues("POST", "/admin/warmup", strconv.Itoa(code)).Inc()
+		return c.Status(code).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	metrics.HTTPRequestsTotal.WithLabelValues("POST", "/admin/warmup", "202").Inc()
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"status": "started",
+	})
+}
+
+// bearerToken returns the access token of an "Authorization: Bearer <token>"
+// header, or an empty string
+func bearerToken(c *fiber.Ctx) string {
+	scheme, token, found := strings.Cut(c.Get(fiber.HeaderAuthorization), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// errorStatus maps domain errors to HTTP status codes
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, auth.ErrInvalidAddress),
+		errors.Is(err, auth.ErrInvalidMessage),
+		errors.Is(err, auth.ErrInvalidStatus),
+		errors.Is(err, auth.ErrInvalidTrustScore),
+		errors.Is(err, auth.ErrBatchTooLarge):
+		return fiber.StatusBadRequest
+	case errors.Is(err, auth.ErrDomainNotAllowed),
+		errors.Is(err, auth.ErrUnauthorized),
+		errors.Is(err, auth.ErrWalletBlocked):
+		return fiber.StatusForbidden
+	case errors.Is(err, auth.ErrInvalidSignature),
+		errors.Is(err, auth.ErrChallengeNotFound),
+		errors.Is(err, auth.ErrChallengeExpired),
+		errors.Is(err, auth.ErrSessionExpired),
+		errors.Is(err, auth.ErrInvalidToken),
+		errors.Is(err, auth.ErrRefreshTokenReused):
+		return fiber.StatusUnauthorized
+	case errors.Is(err, auth.ErrWalletNotFound),
+		errors.Is(err, auth.ErrTxNotFound),
+		errors.Is(err, auth.ErrProposalNotFound),
+		errors.Is(err, auth.ErrSessionNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, auth.ErrProposalClosed),
+		errors.Is(err, auth.ErrAlreadyApproved),
+		errors.Is(err, auth.ErrWarmupRunning):
+		return fiber.StatusConflict
+	case errors.Is(err, auth.ErrRateLimitExceeded):
+		return fiber.StatusTooManyRequests
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
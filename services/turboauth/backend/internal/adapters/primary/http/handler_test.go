@@ -0,0 +1,162 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"turboauth/internal/adapters/secondary/noncestore"
+	"turboauth/internal/adapters/secondary/proposalstore"
+	"turboauth/internal/adapters/secondary/truststore"
+	"turboauth/internal/adapters/secondary/txstore"
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/identity"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var testAdmin = identity.FromPublicKey([identity.PublicKeySize]byte{0xad}, false)
+
+// emptyContract is a contract that knows no wallets
+type emptyContract struct {
+	auth.QubicPort
+}
+
+func (emptyContract) BatchGetAuthStatus(ctx context.Context, walletAddresses []string) ([]auth.StatusResult, error) {
+	results := make([]auth.StatusResult, len(walletAddresses))
+	for i, addr := range walletAddresses {
+		results[i] = auth.StatusResult{WalletAddress: addr, Err: auth.ErrWalletNotFound}
+	}
+	return results, nil
+}
+
+// testVerifier accepts signatures made by sign
+type testVerifier struct{}
+
+func (testVerifier) VerifySignature(ctx context.Context, walletAddress, message, signature string) (bool, error) {
+	return signature == sign(walletAddress, message), nil
+}
+
+func (testVerifier) ValidateAddress(walletAddress string) bool {
+	return identity.Validate(walletAddress) == nil
+}
+
+// sign returns the signature testVerifier accepts from signer over message
+func sign(signer, message string) string {
+	return signer + ":" + message
+}
+
+// walletList is a warm-up source listing fixed wallets
+type walletList []string
+
+func (l walletList) HotWallets(ctx context.Context, limit int) ([]string, error) {
+	return l, nil
+}
+
+// newTestApp serves the API of a service whose only admin is testAdmin
+func newTestApp(t *testing.T) *fiber.App {
+	t.Helper()
+
+	trustStore := truststore.NewMemoryStore(0, 0)
+	nonceStore := noncestore.NewMemoryStore()
+	proposalStore := proposalstore.NewMemoryStore()
+	statusChangeStore := txstore.NewMemoryStore()
+
+	svc := auth.NewService(emptyContract{}, testVerifier{}, trustStore, nonceStore, proposalStore, statusChangeStore,
+		auth.WithAdminPolicy(auth.AdminPolicy{
+			Identities:        []string{testAdmin},
+			MaxRequestAge:     time.Minute,
+			ApprovalThreshold: 1,
+			ProposalTTL:       time.Hour,
+		}),
+	)
+	t.Cleanup(func() {
+		svc.Stop()
+		trustStore.Stop()
+		nonceStore.Stop()
+		proposalStore.Stop()
+		statusChangeStore.Stop()
+	})
+
+	wallet := identity.FromPublicKey([identity.PublicKeySize]byte{0x01}, false)
+	warmer := auth.NewWarmer(svc, auth.WarmupPolicy{}, walletList{wallet})
+
+	app := fiber.New()
+	SetupRoutes(app, NewHandler(svc, warmer))
+	return app
+}
+
+func TestTriggerWarmup(t *testing.T) {
+	app := newTestApp(t)
+
+	// signed returns a warm-up request signed by the test admin
+	signed := func(nonce string) *auth.WarmupRequest {
+		req := &auth.WarmupRequest{AdminAddress: testAdmin, Nonce: nonce, Timestamp: time.Now().Unix()}
+		req.AdminSignature = sign(testAdmin, req.SigningMessage())
+		return req
+	}
+
+	tests := []struct {
+		name string
+		body any
+		want int
+	}{
+		{
+			name: "signed by an admin",
+			body: signed("first"),
+			want: fiber.StatusAccepted,
+		},
+		{
+			name: "replayed nonce",
+			body: signed("first"),
+			want: fiber.StatusForbidden,
+		},
+		{
+			name: "signature over another message",
+			body: func() *auth.WarmupRequest {
+				req := signed("other-message")
+				req.AdminSignature = sign(testAdmin, "TurboAuth Warmup")
+				return req
+			}(),
+			want: fiber.StatusForbidden,
+		},
+		{
+			name: "not an admin",
+			body: func() *auth.WarmupRequest {
+				req := signed("not-admin")
+				req.AdminAddress = identity.FromPublicKey([identity.PublicKeySize]byte{0x02}, false)
+				req.AdminSignature = sign(req.AdminAddress, req.SigningMessage())
+				return req
+			}(),
+			want: fiber.StatusForbidden,
+		},
+		{
+			name: "malformed body",
+			body: "not a request",
+			want: fiber.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest("POST", "/api/v1/admin/warmup", bytes.NewReader(body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("POST /api/v1/admin/warmup: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("POST /api/v1/admin/warmup = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
@@ -22,6 +22,10 @@ func SetupRoutes(app *fiber.App, handler *Handler) {
 
 	// Health check
 	app.Get("/health", handler.HealthCheck)
+	app.Get("/ready", handler.Ready)
+
+	// Session token verification keys
+	app.Get("/.well-known/jwks.json", handler.JWKS)
 
 	// Metrics endpoint
 	app.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
@@ -34,7 +38,27 @@ func SetupRoutes(app *fiber.App, handler *Handler) {
 		v1.Post("/status/batch", handler.BatchGetStatus)
 		v1.Post("/status", handler.SetStatus)
 
+		// Multi-admin status-change proposals
+		v1.Post("/proposals", handler.ProposeStatus)
+		v1.Get("/proposals/:id", handler.GetProposal)
+		v1.Post("/proposals/:id/approve", handler.ApproveProposal)
+
+		// Status-change transaction tracking
+		v1.Get("/tx/:hash", handler.GetTransaction)
+
 		// Wallet verification
+		v1.Post("/challenge", handler.CreateChallenge)
 		v1.Post("/verify", handler.VerifyWallet)
+
+		// Sessions
+		v1.Post("/sessions", handler.CreateSession)
+		v1.Post("/sessions/refresh", handler.RefreshSession)
+		v1.Delete("/sessions/:id", handler.DeleteSession)
+		v1.Get("/wallets/:wallet/sessions", handler.GetActiveSessions)
+		v1.Delete("/wallets/:wallet/sessions", handler.EndAllSessions)
+		v1.Post("/tokens/revoke", handler.RevokeToken)
+
+		// Cache warm-up
+		v1.Post("/admin/warmup", handler.TriggerWarmup)
 	}
 }
@@ -0,0 +1,62 @@
+package accessstats
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// maxTrackedWallets bounds how many distinct wallets are counted; once it is
+// reached only wallets already tracked are counted
+const maxTrackedWallets = 100000
+
+// MemoryStore counts wallet lookups in memory since the process started
+// Counts are lost on restart, so it only helps warm-ups triggered later on.
+type MemoryStore struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+// NewMemoryStore creates a new in-memory access statistics store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		counts: make(map[string]int64),
+	}
+}
+
+// RecordAccess counts a lookup of each wallet
+func (m *MemoryStore) RecordAccess(walletAddresses ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record(m.counts, walletAddresses)
+}
+
+// HotWallets returns up to limit of the most looked-up wallets
+func (m *MemoryStore) HotWallets(ctx context.Context, limit int) ([]string, error) {
+	m.mu.Lock()
+	wallets := make([]string, 0, len(m.counts))
+	counts := make(map[string]int64, len(m.counts))
+	for addr, count := range m.counts {
+		wallets = append(wallets, addr)
+		counts[addr] = count
+	}
+	m.mu.Unlock()
+
+	sort.Slice(wallets, func(i, j int) bool {
+		return counts[wallets[i]] > counts[wallets[j]]
+	})
+	if limit > 0 && len(wallets) > limit {
+		wallets = wallets[:limit]
+	}
+	return wallets, nil
+}
+
+// record adds one lookup of each wallet to counts
+func record(counts map[string]int64, walletAddresses []string) {
+	for _, addr := range walletAddresses {
+		if _, ok := counts[addr]; ok || len(counts) < maxTrackedWallets {
+			counts[addr]++
+		}
+	}
+}
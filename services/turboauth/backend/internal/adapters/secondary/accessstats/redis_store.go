@@ -0,0 +1,164 @@
+package accessstats
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+	"time"
+
+	"turboauth/pkg/redisclient"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// flushInterval is how often buffered counts are written to Redis
+	flushInterval = 10 * time.Second
+
+	// bucketWidth is the time span counted in one sorted set
+	bucketWidth = time.Hour
+
+	// windowBuckets is how many of the latest buckets make up "recent" accesses
+	windowBuckets = 24
+)
+
+// RedisStore counts wallet lookups of all instances in Redis
+// Lookups are buffered in memory and added to an hourly sorted set every few
+// seconds, so recording never waits on Redis. The hottest wallets are those
+// with the most lookups over the last day.
+type RedisStore struct {
+	client redis.UniversalClient
+	keys   redisclient.Keyspace
+
+	mu      sync.Mutex
+	pending map[string]int64
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRedisStore creates a new Redis access statistics store
+func NewRedisStore(opts redisclient.Options) (*RedisStore, error) {
+	client, err := redisclient.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	store := &RedisStore{
+		client:  client,
+		keys:    opts.Keyspace(),
+		pending: make(map[string]int64),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	// Start flush goroutine
+	go store.flushPeriodically()
+
+	return store, nil
+}
+
+// RecordAccess counts a lookup of each wallet
+func (r *RedisStore) RecordAccess(walletAddresses ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record(r.pending, walletAddresses)
+}
+
+// HotWallets returns up to limit of the most looked-up wallets over the last day
+func (r *RedisStore) HotWallets(ctx context.Context, limit int) ([]string, error) {
+	now := time.Now()
+	buckets := make([]string, 0, windowBuckets)
+	for i := 0; i < windowBuckets; i++ {
+		buckets = append(buckets, r.bucketKey(now.Add(-time.Duration(i)*bucketWidth)))
+	}
+
+	// Sum the buckets into a temporary set; it is in the buckets' group, so
+	// this also works in cluster mode
+	tmp := r.keys.GroupKey("access", "top:"+randomID())
+	stop := int64(limit) - 1
+	if limit <= 0 {
+		stop = -1
+	}
+
+	pipe := r.client.Pipeline()
+	pipe.ZUnionStore(ctx, tmp, &redis.ZStore{Keys: buckets})
+	top := pipe.ZRevRange(ctx, tmp, 0, stop)
+	pipe.Del(ctx, tmp)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	return top.Val(), nil
+}
+
+// Close flushes buffered counts and closes the Redis connection
+func (r *RedisStore) Close() error {
+	r.stopOnce.Do(func() {
+		close(r.stop)
+		<-r.done
+	})
+	return r.client.Close()
+}
+
+// flushPeriodically writes buffered counts until the store is closed
+func (r *RedisStore) flushPeriodically() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.stop:
+			r.flush()
+			return
+		case <-ticker.C:
+			r.flush()
+		}
+	}
+}
+
+// flush adds buffered counts to the current bucket
+// Statistics are best effort: counts that cannot be written are dropped.
+func (r *RedisStore) flush() {
+	r.mu.Lock()
+	counts := r.pending
+	r.pending = make(map[string]int64)
+	r.mu.Unlock()
+
+	if len(counts) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), flushInterval)
+	defer cancel()
+
+	key := r.bucketKey(time.Now())
+	pipe := r.client.Pipeline()
+	for addr, count := range counts {
+		pipe.ZIncrBy(ctx, key, float64(count), addr)
+	}
+	pipe.Expire(ctx, key, (windowBuckets+1)*bucketWidth)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Debug().Err(err).Int("wallets", len(counts)).Msg("Failed to record access statistics")
+	}
+}
+
+// bucketKey returns the sorted set counting lookups in the hour containing t
+// The buckets of a day are summed by ZUNIONSTORE, so they share a slot.
+func (r *RedisStore) bucketKey(t time.Time) string {
+	return r.keys.GroupKey("access", strconv.FormatInt(t.Unix()/int64(bucketWidth/time.Second), 10))
+}
+
+func randomID() string {
+	bytes := make([]byte, 8)
+	_, _ = rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
@@ -0,0 +1,116 @@
+package noncestore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"turboauth/internal/domain/auth"
+)
+
+// MemoryStore implements an in-memory challenge store (single instance only)
+type MemoryStore struct {
+	mu         sync.Mutex
+	challenges map[string]*auth.Challenge
+	nonces     map[string]time.Time
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewMemoryStore creates a new in-memory challenge store
+func NewMemoryStore() *MemoryStore {
+	store := &MemoryStore{
+		challenges: make(map[string]*auth.Challenge),
+		nonces:     make(map[string]time.Time),
+		stop:       make(chan struct{}),
+	}
+
+	// Start cleanup goroutine
+	go store.cleanupExpired()
+
+	return store
+}
+
+// StoreChallenge records an issued challenge until it expires
+func (m *MemoryStore) StoreChallenge(ctx context.Context, challenge *auth.Challenge) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.challenges[messageKey(challenge.Message)] = challenge
+	return nil
+}
+
+// ConsumeChallenge removes and returns the challenge issued for message
+func (m *MemoryStore) ConsumeChallenge(ctx context.Context, message string) (*auth.Challenge, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := messageKey(message)
+	challenge, ok := m.challenges[key]
+	if !ok {
+		return nil, auth.ErrChallengeNotFound
+	}
+	delete(m.challenges, key)
+
+	if time.Now().After(challenge.ExpiresAt) {
+		return nil, auth.ErrChallengeNotFound
+	}
+	return challenge, nil
+}
+
+// ClaimNonce records a single-use nonce until ttl elapses
+func (m *MemoryStore) ClaimNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if expiresAt, ok := m.nonces[nonce]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	m.nonces[nonce] = now.Add(ttl)
+	return true, nil
+}
+
+// Stop ends the cleanup goroutine
+func (m *MemoryStore) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
+
+// cleanupExpired removes expired challenges and nonces periodically
+func (m *MemoryStore) cleanupExpired() {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		m.mu.Lock()
+		for key, challenge := range m.challenges {
+			if now.After(challenge.ExpiresAt) {
+				delete(m.challenges, key)
+			}
+		}
+		for nonce, expiresAt := range m.nonces {
+			if now.After(expiresAt) {
+				delete(m.nonces, nonce)
+			}
+		}
+		m.mu.Unlock()
+	}
+}
+
+// messageKey derives the store key for a challenge message
+func messageKey(message string) string {
+	sum := sha256.Sum256([]byte(message))
+	return hex.EncodeToString(sum[:])
+}
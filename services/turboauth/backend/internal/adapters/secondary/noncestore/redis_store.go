@@ -0,0 +1,75 @@
+package noncestore
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/redisclient"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore implements a Redis-based challenge store shared by all instances
+type RedisStore struct {
+	client redis.UniversalClient
+	keys   redisclient.Keyspace
+}
+
+// NewRedisStore creates a new Redis challenge store
+func NewRedisStore(opts redisclient.Options) (*RedisStore, error) {
+	client, err := redisclient.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisStore{client: client, keys: opts.Keyspace()}, nil
+}
+
+// StoreChallenge records an issued challenge with a TTL matching its expiry
+func (r *RedisStore) StoreChallenge(ctx context.Context, challenge *auth.Challenge) error {
+	ttl := time.Until(challenge.ExpiresAt)
+	if ttl <= 0 {
+		return auth.ErrChallengeExpired
+	}
+
+	data, err := json.Marshal(challenge)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(ctx, r.challengeKey(challenge.Message), data, ttl).Err()
+}
+
+// ConsumeChallenge atomically removes and returns the challenge issued for message
+func (r *RedisStore) ConsumeChallenge(ctx context.Context, message string) (*auth.Challenge, error) {
+	data, err := r.client.GetDel(ctx, r.challengeKey(message)).Bytes()
+	if err == redis.Nil {
+		return nil, auth.ErrChallengeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var challenge auth.Challenge
+	if err := json.Unmarshal(data, &challenge); err != nil {
+		return nil, err
+	}
+
+	return &challenge, nil
+}
+
+// ClaimNonce records a single-use nonce with the given TTL
+func (r *RedisStore) ClaimNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	return r.client.SetNX(ctx, r.keys.Key("nonce", nonce), 1, ttl).Result()
+}
+
+// Close closes the Redis connection
+func (r *RedisStore) Close() error {
+	return r.client.Close()
+}
+
+func (r *RedisStore) challengeKey(message string) string {
+	return r.keys.Key("challenge", messageKey(message))
+}
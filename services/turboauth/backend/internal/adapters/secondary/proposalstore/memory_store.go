@@ -0,0 +1,111 @@
+package proposalstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"turboauth/internal/domain/auth"
+)
+
+// retention is how long proposals remain queryable after they expire
+const retention = 24 * time.Hour
+
+// MemoryStore implements an in-memory proposal store (single instance only)
+type MemoryStore struct {
+	mu        sync.Mutex
+	proposals map[string]*auth.Proposal
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewMemoryStore creates a new in-memory proposal store
+func NewMemoryStore() *MemoryStore {
+	store := &MemoryStore{
+		proposals: make(map[string]*auth.Proposal),
+		stop:      make(chan struct{}),
+	}
+
+	// Start cleanup goroutine
+	go store.cleanupExpired()
+
+	return store
+}
+
+// CreateProposal stores a new proposal
+func (m *MemoryStore) CreateProposal(ctx context.Context, proposal *auth.Proposal) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.proposals[proposal.ProposalID] = clone(proposal)
+	return nil
+}
+
+// GetProposal retrieves a proposal by ID
+func (m *MemoryStore) GetProposal(ctx context.Context, proposalID string) (*auth.Proposal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	proposal, ok := m.proposals[proposalID]
+	if !ok {
+		return nil, auth.ErrProposalNotFound
+	}
+	return clone(proposal), nil
+}
+
+// UpdateProposal applies update to a stored proposal under the store lock
+func (m *MemoryStore) UpdateProposal(ctx context.Context, proposalID string, update func(*auth.Proposal) error) (*auth.Proposal, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.proposals[proposalID]
+	if !ok {
+		return nil, auth.ErrProposalNotFound
+	}
+
+	proposal := clone(stored)
+	if err := update(proposal); err != nil {
+		return nil, err
+	}
+	m.proposals[proposalID] = proposal
+
+	return clone(proposal), nil
+}
+
+// Stop ends the cleanup goroutine
+func (m *MemoryStore) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
+
+// cleanupExpired removes old proposals periodically
+func (m *MemoryStore) cleanupExpired() {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		m.mu.Lock()
+		for id, proposal := range m.proposals {
+			if now.After(proposal.ExpiresAt.Add(retention)) {
+				delete(m.proposals, id)
+			}
+		}
+		m.mu.Unlock()
+	}
+}
+
+// clone copies a proposal so callers never share its approvals slice
+func clone(proposal *auth.Proposal) *auth.Proposal {
+	copied := *proposal
+	copied.Approvals = append([]auth.Approval(nil), proposal.Approvals...)
+	return &copied
+}
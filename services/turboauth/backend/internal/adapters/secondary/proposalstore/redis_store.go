@@ -0,0 +1,127 @@
+package proposalstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/redisclient"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// maxUpdateRetries bounds optimistic-locking retries under contention
+const maxUpdateRetries = 10
+
+// RedisStore implements a Redis-based proposal store shared by all instances
+type RedisStore struct {
+	client redis.UniversalClient
+	keys   redisclient.Keyspace
+}
+
+// NewRedisStore creates a new Redis proposal store
+func NewRedisStore(opts redisclient.Options) (*RedisStore, error) {
+	client, err := redisclient.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisStore{client: client, keys: opts.Keyspace()}, nil
+}
+
+// CreateProposal stores a new proposal until it has expired and its retention has passed
+func (r *RedisStore) CreateProposal(ctx context.Context, proposal *auth.Proposal) error {
+	data, err := json.Marshal(proposal)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(ctx, r.key(proposal.ProposalID), data, ttl(proposal)).Err()
+}
+
+// GetProposal retrieves a proposal by ID
+func (r *RedisStore) GetProposal(ctx context.Context, proposalID string) (*auth.Proposal, error) {
+	data, err := r.client.Get(ctx, r.key(proposalID)).Bytes()
+	if err == redis.Nil {
+		return nil, auth.ErrProposalNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var proposal auth.Proposal
+	if err := json.Unmarshal(data, &proposal); err != nil {
+		return nil, err
+	}
+
+	return &proposal, nil
+}
+
+// UpdateProposal applies update to a stored proposal using optimistic locking,
+// retrying if another instance changed it concurrently
+func (r *RedisStore) UpdateProposal(ctx context.Context, proposalID string, update func(*auth.Proposal) error) (*auth.Proposal, error) {
+	key := r.key(proposalID)
+
+	var result *auth.Proposal
+	txf := func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return auth.ErrProposalNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var proposal auth.Proposal
+		if err := json.Unmarshal(data, &proposal); err != nil {
+			return err
+		}
+		if err := update(&proposal); err != nil {
+			return err
+		}
+
+		data, err = json.Marshal(&proposal)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, ttl(&proposal))
+			return nil
+		})
+		if err == nil {
+			result = &proposal
+		}
+		return err
+	}
+
+	for i := 0; i < maxUpdateRetries; i++ {
+		err := r.client.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("proposal %s: too much contention", proposalID)
+}
+
+// Close closes the Redis connection
+func (r *RedisStore) Close() error {
+	return r.client.Close()
+}
+
+// ttl keeps a proposal until its retention after expiry has passed
+func ttl(proposal *auth.Proposal) time.Duration {
+	return time.Until(proposal.ExpiresAt.Add(retention))
+}
+
+func (r *RedisStore) key(proposalID string) string {
+	return r.keys.Key("proposal", proposalID)
+}
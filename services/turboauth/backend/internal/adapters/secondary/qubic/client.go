@@ -1,100 +1,314 @@
 package qubic
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"turboauth/internal/domain/auth"
+	"turboauth/pkg/identity"
 
 	"github.com/rs/zerolog/log"
 )
 
-// Client implements the Qubic blockchain client
+// Contract function indices (inputType). Qubic numbers functions and
+// procedures separately, both starting at 1.
+const (
+	getStatusFunction  = 1
+	setStatusProcedure = 1
+)
+
+// txTickOffset is how many ticks ahead of the current tick transactions are scheduled
+const txTickOffset = 10
+
+// txExpiryTicks is how many ticks past its target tick a transaction may still
+// show up in tx-status before it is considered dropped
+const txExpiryTicks = 5
+
+// walletAuthDataSize is the encoded size of WalletAuthData from microauth.hpp:
+// AuthStatus (int32), trustScore (int32), updatedAt (int64), little-endian
+const walletAuthDataSize = 16
+
+// BatchConfig bounds the parallel queries of BatchGetAuthStatus
+type BatchConfig struct {
+	// Concurrency is how many wallets are queried at once
+	Concurrency int
+
+	// CallTimeout bounds each query; zero leaves only the HTTP client timeout
+	CallTimeout time.Duration
+}
+
+// Client implements the Qubic blockchain client on top of the node's RPC API
 type Client struct {
 	nodeURL         string
 	contractAddress string
-	// TODO: Add actual Qubic RPC client
+	contractIndex   uint32
+	httpClient      *http.Client
+	signer          *signer // nil when no admin seed is configured (read-only)
+	batch           BatchConfig
+
+	// submitted maps transaction ids broadcast by this client to a submittedTx
+	submitted sync.Map
+}
+
+// submittedTx is what the client remembers of a transaction it broadcast
+type submittedTx struct {
+	tick   uint32
+	amount int64
 }
 
 // NewClient creates a new Qubic client
-func NewClient(nodeURL, contractAddress string) *Client {
-	return &Client{
-		nodeURL:         nodeURL,
+// The contract address must be the identity of a deployed contract, whose
+// public key carries the contract index in its first 8 bytes. The admin seed
+// is used to sign status updates; without it the client is read-only.
+func NewClient(nodeURL, contractAddress, adminSeed string, batch BatchConfig) (*Client, error) {
+	contractIndex, err := contractIndexFromAddress(contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if batch.Concurrency < 1 {
+		batch.Concurrency = 1
+	}
+
+	// Keep a connection per batch worker alive between queries
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = batch.Concurrency
+
+	client := &Client{
+		nodeURL:         strings.TrimRight(nodeURL, "/"),
 		contractAddress: contractAddress,
+		contractIndex:   contractIndex,
+		httpClient: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: transport,
+		},
+		batch: batch,
+	}
+
+	if adminSeed != "" {
+		client.signer, err = newSigner(adminSeed)
+		if err != nil {
+			return nil, err
+		}
+		log.Info().Str("admin", client.signer.identity()).Msg("Qubic admin signer configured")
+	} else {
+		log.Warn().Msg("No Qubic admin seed configured, status updates are disabled")
 	}
+
+	return client, nil
+}
+
+// NodeError is returned (wrapped in auth.ErrBlockchainFailure) when the node
+// answers a request with an error, e.g. when it rejects a transaction
+type NodeError struct {
+	Path       string
+	StatusCode int
+	Message    string
+}
+
+func (e *NodeError) Error() string {
+	return fmt.Sprintf("node returned %d for %s: %s", e.StatusCode, e.Path, e.Message)
+}
+
+// querySmartContractRequest is the body of POST /v1/querySmartContract
+type querySmartContractRequest struct {
+	ContractIndex uint32 `json:"contractIndex"`
+	InputType     uint16 `json:"inputType"`
+	InputSize     uint16 `json:"inputSize"`
+	RequestData   string `json:"requestData"`
+}
+
+// querySmartContractResponse is the response of POST /v1/querySmartContract
+type querySmartContractResponse struct {
+	ResponseData string `json:"responseData"`
+}
+
+// broadcastTransactionRequest is the body of POST /v1/broadcast-transaction
+type broadcastTransactionRequest struct {
+	EncodedTransaction string `json:"encodedTransaction"`
+}
+
+// broadcastTransactionResponse is the response of POST /v1/broadcast-transaction
+type broadcastTransactionResponse struct {
+	PeersBroadcasted   int    `json:"peersBroadcasted"`
+	EncodedTransaction string `json:"encodedTransaction"`
+	TransactionID      string `json:"transactionId"`
+}
+
+// txStatusResponse is the response of GET /v1/tx-status/{txId}
+type txStatusResponse struct {
+	TransactionStatus struct {
+		TxID      string `json:"txId"`
+		MoneyFlew bool   `json:"moneyFlew"`
+	} `json:"transactionStatus"`
+}
+
+// tickInfoResponse is the response of GET /v1/tick-info
+type tickInfoResponse struct {
+	TickInfo struct {
+		Tick        uint32 `json:"tick"`
+		Duration    uint32 `json:"duration"`
+		Epoch       uint32 `json:"epoch"`
+		InitialTick uint32 `json:"initialTick"`
+	} `json:"tickInfo"`
 }
 
 // GetAuthStatus retrieves authentication status from the smart contract
-// TODO: Implement actual Qubic RPC calls
 func (c *Client) GetAuthStatus(ctx context.Context, walletAddress string) (*auth.WalletAuth, error) {
 	log.Debug().
 		Str("wallet", walletAddress).
 		Str("contract", c.contractAddress).
 		Msg("Querying Qubic smart contract")
 
-	// Placeholder implementation
-	// In production, this would make an RPC call to the Qubic node
-	// to query the smart contract state
-
-	// Simulate blockchain latency
-	time.Sleep(100 * time.Millisecond)
+	publicKey, err := identity.PublicKey(walletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", auth.ErrInvalidAddress, err)
+	}
 
-	// TODO: Replace with actual RPC call
-	// Example: result, err := c.rpcClient.Call("getAuthStatus", walletAddress)
+	output, err := c.querySmartContract(ctx, getStatusFunction, publicKey[:])
+	if err != nil {
+		return nil, err
+	}
 
-	return &auth.WalletAuth{
-		WalletAddress:   walletAddress,
-		Status:          auth.StatusActive,
-		TrustScore:      100,
-		ContractAddress: c.contractAddress,
-		UpdatedAt:       time.Now(),
-		CreatedAt:       time.Now(),
-	}, nil
+	return c.decodeWalletAuth(walletAddress, output)
 }
 
 // SetAuthStatus updates authentication status on the smart contract
-// TODO: Implement actual transaction submission
+// It builds a setStatus invocation, signs it with the admin seed and
+// broadcasts it, returning the transaction id. The transaction is only
+// scheduled for a future tick; inclusion is not awaited here.
 func (c *Client) SetAuthStatus(ctx context.Context, req *auth.SetStatusRequest) (string, error) {
+	if c.signer == nil {
+		return "", fmt.Errorf("%w: no admin seed configured", auth.ErrBlockchainFailure)
+	}
+
 	log.Info().
 		Str("wallet", req.WalletAddress).
 		Str("status", string(req.Status)).
 		Int("trust_score", req.TrustScore).
 		Msg("Updating status on blockchain")
 
-	// Placeholder implementation
-	// In production, this would:
-	// 1. Create a transaction to call the smart contract
-	// 2. Sign the transaction with admin key
-	// 3. Submit to the Qubic network
-	// 4. Wait for confirmation
+	input, err := encodeSetStatusInput(req)
+	if err != nil {
+		return "", err
+	}
+
+	tick, err := c.currentTick(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	tx := &transaction{
+		sourcePublicKey:      c.signer.publicKey,
+		destinationPublicKey: contractPublicKey(c.contractIndex),
+		tick:                 tick + txTickOffset,
+		inputType:            setStatusProcedure,
+		input:                input,
+	}
+	tx.sign(c.signer)
+
+	return c.broadcastTransaction(ctx, tx)
+}
+
+// GetTransactionState reports whether a submitted transaction has been included
+// A transaction the node does not know is pending until the network has moved
+// past its target tick; for transactions not broadcast by this client the
+// target tick is unknown and it stays pending. An included transaction that
+// carried an amount failed if the amount did not move; transactions without
+// one never move money, so inclusion is all the node can tell about them.
+func (c *Client) GetTransactionState(ctx context.Context, txHash string) (*auth.TxResult, error) {
+	var resp txStatusResponse
+	err := c.do(ctx, http.MethodGet, "/v1/tx-status/"+url.PathEscape(txHash), nil, &resp)
+	if err == nil {
+		value, _ := c.submitted.LoadAndDelete(txHash)
+		if tx, ok := value.(submittedTx); ok && tx.amount > 0 && !resp.TransactionStatus.MoneyFlew {
+			return &auth.TxResult{State: auth.TxFailed, Reason: "transaction was included but its amount was not transferred"}, nil
+		}
+		return &auth.TxResult{State: auth.TxConfirmed}, nil
+	}
+
+	var nodeErr *NodeError
+	if !errors.As(err, &nodeErr) || nodeErr.StatusCode != http.StatusNotFound {
+		return nil, err
+	}
 
-	// Simulate transaction time
-	time.Sleep(200 * time.Millisecond)
+	value, ok := c.submitted.Load(txHash)
+	if !ok {
+		return &auth.TxResult{State: auth.TxPending}, nil
+	}
 
-	// TODO: Replace with actual transaction
-	txHash := fmt.Sprintf("0x%x", time.Now().UnixNano())
+	tick, err := c.currentTick(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if tick > value.(submittedTx).tick+txExpiryTicks {
+		c.submitted.Delete(txHash)
+		return &auth.TxResult{State: auth.TxFailed, Reason: "transaction was not included before its target tick"}, nil
+	}
 
-	return txHash, nil
+	return &auth.TxResult{State: auth.TxPending}, nil
 }
 
 // BatchGetAuthStatus retrieves multiple statuses efficiently
-// TODO: Implement batch RPC call or parallel queries
-func (c *Client) BatchGetAuthStatus(ctx context.Context, walletAddresses []string) ([]*auth.WalletAuth, error) {
-	result := make([]*auth.WalletAuth, 0, len(walletAddresses))
-
-	// For now, query sequentially
-	// TODO: Implement parallel queries or batch RPC call
-	for _, addr := range walletAddresses {
-		status, err := c.GetAuthStatus(ctx, addr)
-		if err != nil {
-			log.Warn().Err(err).Str("wallet", addr).Msg("Failed to get status")
-			continue
+// The node has no batch query, so wallets are queried in parallel by a
+// bounded pool of workers, each query with its own timeout. Failures are
+// reported per wallet; wallets not queried before ctx ends get ctx's error.
+func (c *Client) BatchGetAuthStatus(ctx context.Context, walletAddresses []string) ([]auth.StatusResult, error) {
+	results := make([]auth.StatusResult, len(walletAddresses))
+	for i, addr := range walletAddresses {
+		results[i].WalletAddress = addr
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(c.batch.Concurrency, len(walletAddresses)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i].Status, results[i].Err = c.getAuthStatusWithTimeout(ctx, walletAddresses[i])
+			}
+		}()
+	}
+
+	for i := range walletAddresses {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			results[i].Err = ctx.Err()
 		}
-		result = append(result, status)
 	}
+	close(indexes)
+	wg.Wait()
 
-	return result, nil
+	for _, result := range results {
+		if result.Err != nil && !errors.Is(result.Err, auth.ErrWalletNotFound) {
+			log.Warn().Err(result.Err).Str("wallet", result.WalletAddress).Msg("Failed to get status")
+		}
+	}
+
+	return results, nil
+}
+
+// getAuthStatusWithTimeout queries one wallet, bounded by the per-call timeout
+func (c *Client) getAuthStatusWithTimeout(ctx context.Context, walletAddress string) (*auth.WalletAuth, error) {
+	if c.batch.CallTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.batch.CallTimeout)
+		defer cancel()
+	}
+	return c.GetAuthStatus(ctx, walletAddress)
 }
 
 // GetContractAddress returns the smart contract address
@@ -104,7 +318,240 @@ func (c *Client) GetContractAddress() string {
 
 // HealthCheck verifies connection to the Qubic node
 func (c *Client) HealthCheck(ctx context.Context) error {
-	// TODO: Implement actual health check (e.g., query node status)
-	log.Debug().Str("node_url", c.nodeURL).Msg("Health check")
+	tick, err := c.currentTick(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Debug().
+		Str("node_url", c.nodeURL).
+		Uint32("tick", tick).
+		Msg("Health check")
+	return nil
+}
+
+// currentTick returns the tick the node is currently processing
+func (c *Client) currentTick(ctx context.Context) (uint32, error) {
+	var resp tickInfoResponse
+	if err := c.do(ctx, http.MethodGet, "/v1/tick-info", nil, &resp); err != nil {
+		return 0, err
+	}
+	return resp.TickInfo.Tick, nil
+}
+
+// broadcastTransaction submits a signed transaction and returns its id
+func (c *Client) broadcastTransaction(ctx context.Context, tx *transaction) (string, error) {
+	txID := tx.id()
+	req := broadcastTransactionRequest{
+		EncodedTransaction: base64.StdEncoding.EncodeToString(tx.bytes()),
+	}
+
+	var resp broadcastTransactionResponse
+	if err := c.do(ctx, http.MethodPost, "/v1/broadcast-transaction", req, &resp); err != nil {
+		return "", err
+	}
+
+	if resp.PeersBroadcasted == 0 {
+		return "", fmt.Errorf("%w: transaction %s was not accepted by any peer", auth.ErrBlockchainFailure, txID)
+	}
+	if resp.TransactionID != "" && resp.TransactionID != txID {
+		log.Warn().
+			Str("tx_hash", txID).
+			Str("node_tx_hash", resp.TransactionID).
+			Msg("Node reported a different transaction id")
+		txID = resp.TransactionID
+	}
+	c.submitted.Store(txID, submittedTx{tick: tx.tick, amount: tx.amount})
+
+	log.Info().
+		Str("tx_hash", txID).
+		Uint32("tick", tx.tick).
+		Int("peers", resp.PeersBroadcasted).
+		Msg("Transaction broadcast")
+
+	return txID, nil
+}
+
+// querySmartContract calls a read-only contract function and returns its raw output
+func (c *Client) querySmartContract(ctx context.Context, inputType uint16, input []byte) ([]byte, error) {
+	req := querySmartContractRequest{
+		ContractIndex: c.contractIndex,
+		InputType:     inputType,
+		InputSize:     uint16(len(input)),
+		RequestData:   base64.StdEncoding.EncodeToString(input),
+	}
+
+	var resp querySmartContractResponse
+	if err := c.do(ctx, http.MethodPost, "/v1/querySmartContract", req, &resp); err != nil {
+		return nil, err
+	}
+
+	output, err := base64.StdEncoding.DecodeString(resp.ResponseData)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid response data: %v", auth.ErrBlockchainFailure, err)
+	}
+	return output, nil
+}
+
+// do performs a JSON request against the node RPC
+// Transport errors and non-2xx responses are wrapped in auth.ErrBlockchainFailure;
+// the latter also carry a *NodeError with the node's reason.
+func (c *Client) do(ctx context.Context, method, path string, body, out interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.nodeURL+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: node unreachable: %v", auth.ErrBlockchainFailure, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%w: %v", auth.ErrBlockchainFailure, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: %w", auth.ErrBlockchainFailure, &NodeError{
+			Path:       path,
+			StatusCode: resp.StatusCode,
+			Message:    nodeErrorMessage(data),
+		})
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("%w: invalid response from %s: %v", auth.ErrBlockchainFailure, path, err)
+	}
 	return nil
 }
+
+// decodeWalletAuth decodes a WalletAuthData record returned by getStatus
+func (c *Client) decodeWalletAuth(walletAddress string, data []byte) (*auth.WalletAuth, error) {
+	// The node returns no data when the contract has nothing to report
+	if len(data) == 0 {
+		return nil, auth.ErrWalletNotFound
+	}
+	if len(data) < walletAuthDataSize {
+		return nil, fmt.Errorf("%w: getStatus returned %d bytes, expected %d",
+			auth.ErrBlockchainFailure, len(data), walletAuthDataSize)
+	}
+
+	status, err := statusFromContract(int32(binary.LittleEndian.Uint32(data[0:4])))
+	if err != nil {
+		return nil, err
+	}
+	if status == auth.StatusUnknown {
+		return nil, auth.ErrWalletNotFound
+	}
+
+	updatedAt := time.Unix(int64(binary.LittleEndian.Uint64(data[8:16])), 0)
+
+	// The contract does not record a creation time, so CreatedAt is left unset
+	return &auth.WalletAuth{
+		WalletAddress:   walletAddress,
+		Status:          status,
+		TrustScore:      int(int32(binary.LittleEndian.Uint32(data[4:8]))),
+		ContractAddress: c.contractAddress,
+		UpdatedAt:       updatedAt,
+	}, nil
+}
+
+// statusFromContract maps the contract's AuthStatus enum to the domain status
+func statusFromContract(value int32) (auth.AuthStatus, error) {
+	switch value {
+	case 0:
+		return auth.StatusUnknown, nil
+	case 1:
+		return auth.StatusActive, nil
+	case 2:
+		return auth.StatusBlocked, nil
+	case 3:
+		return auth.StatusReview, nil
+	default:
+		return "", fmt.Errorf("%w: unknown contract status %d", auth.ErrBlockchainFailure, value)
+	}
+}
+
+// statusToContract maps a domain status to the contract's AuthStatus enum
+func statusToContract(status auth.AuthStatus) (int32, error) {
+	switch status {
+	case auth.StatusActive:
+		return 1, nil
+	case auth.StatusBlocked:
+		return 2, nil
+	case auth.StatusReview:
+		return 3, nil
+	default:
+		return 0, auth.ErrInvalidStatus
+	}
+}
+
+// encodeSetStatusInput encodes the setStatus procedure input:
+// wallet public key (32), AuthStatus (int32), trustScore (int32)
+func encodeSetStatusInput(req *auth.SetStatusRequest) ([]byte, error) {
+	publicKey, err := identity.PublicKey(req.WalletAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", auth.ErrInvalidAddress, err)
+	}
+
+	status, err := statusToContract(req.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.TrustScore < 0 || req.TrustScore > 100 {
+		return nil, auth.ErrInvalidTrustScore
+	}
+
+	input := make([]byte, 40)
+	copy(input[0:32], publicKey[:])
+	binary.LittleEndian.PutUint32(input[32:36], uint32(status))
+	binary.LittleEndian.PutUint32(input[36:40], uint32(int32(req.TrustScore)))
+	return input, nil
+}
+
+// contractIndexFromAddress extracts the contract index from a contract identity
+func contractIndexFromAddress(contractAddress string) (uint32, error) {
+	publicKey, err := identity.PublicKey(contractAddress)
+	if err != nil {
+		return 0, fmt.Errorf("invalid contract address %q: %w", contractAddress, err)
+	}
+
+	index := binary.LittleEndian.Uint64(publicKey[0:8])
+	for _, b := range publicKey[8:] {
+		if b != 0 {
+			return 0, fmt.Errorf("contract address %q is not a contract identity", contractAddress)
+		}
+	}
+	if index == 0 || index > 0xFFFFFFFF {
+		return 0, fmt.Errorf("contract address %q has invalid index %d", contractAddress, index)
+	}
+
+	return uint32(index), nil
+}
+
+// nodeErrorMessage extracts the error message from a node error response
+func nodeErrorMessage(data []byte) string {
+	var errResp struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	}
+	if json.Unmarshal(data, &errResp) == nil && errResp.Message != "" {
+		return errResp.Message
+	}
+	return strings.TrimSpace(string(data))
+}
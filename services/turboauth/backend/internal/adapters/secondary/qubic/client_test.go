@@ -0,0 +1,404 @@
+package qubic
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/identity"
+)
+
+// testContractIndex is the contract index of the stand-in node's contract
+const testContractIndex = 13
+
+var testWallet = identity.FromPublicKey([identity.PublicKeySize]byte{1, 2, 3}, false)
+
+// newTestClient starts a stand-in node serving handler and returns a client for it
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	return newTestBatchClient(t, handler, BatchConfig{})
+}
+
+// newTestBatchClient is newTestClient with batch queries configured by batch
+func newTestBatchClient(t *testing.T, handler http.HandlerFunc, batch BatchConfig) *Client {
+	t.Helper()
+
+	node := httptest.NewServer(handler)
+	t.Cleanup(node.Close)
+
+	contractAddress := identity.FromPublicKey(contractPublicKey(testContractIndex), false)
+	client, err := NewClient(node.URL, contractAddress, "", batch)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return client
+}
+
+// walletAuthData encodes a WalletAuthData record as the contract returns it
+func walletAuthData(status, trustScore int32, updatedAt int64) []byte {
+	data := make([]byte, walletAuthDataSize)
+	binary.LittleEndian.PutUint32(data[0:4], uint32(status))
+	binary.LittleEndian.PutUint32(data[4:8], uint32(trustScore))
+	binary.LittleEndian.PutUint64(data[8:16], uint64(updatedAt))
+	return data
+}
+
+// respondWith returns a handler answering every query with the given output
+func respondWith(output []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(querySmartContractResponse{
+			ResponseData: base64.StdEncoding.EncodeToString(output),
+		})
+	}
+}
+
+func TestGetAuthStatus(t *testing.T) {
+	updatedAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    *auth.WalletAuth
+		wantErr error
+	}{
+		{
+			name:    "active",
+			handler: respondWith(walletAuthData(1, 87, updatedAt.Unix())),
+			want:    &auth.WalletAuth{Status: auth.StatusActive, TrustScore: 87, UpdatedAt: updatedAt},
+		},
+		{
+			name:    "blocked",
+			handler: respondWith(walletAuthData(2, 0, updatedAt.Unix())),
+			want:    &auth.WalletAuth{Status: auth.StatusBlocked, TrustScore: 0, UpdatedAt: updatedAt},
+		},
+		{
+			name:    "review",
+			handler: respondWith(walletAuthData(3, 50, updatedAt.Unix())),
+			want:    &auth.WalletAuth{Status: auth.StatusReview, TrustScore: 50, UpdatedAt: updatedAt},
+		},
+		{
+			name:    "unknown status",
+			handler: respondWith(walletAuthData(0, 0, 0)),
+			wantErr: auth.ErrWalletNotFound,
+		},
+		{
+			name:    "empty response data",
+			handler: respondWith(nil),
+			wantErr: auth.ErrWalletNotFound,
+		},
+		{
+			name:    "short response data",
+			handler: respondWith(walletAuthData(1, 87, updatedAt.Unix())[:8]),
+			wantErr: auth.ErrBlockchainFailure,
+		},
+		{
+			name:    "status outside the contract enum",
+			handler: respondWith(walletAuthData(7, 87, updatedAt.Unix())),
+			wantErr: auth.ErrBlockchainFailure,
+		},
+		{
+			name: "node error",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				_, _ = io.WriteString(w, `{"code":14,"message":"node is syncing"}`)
+			},
+			wantErr: auth.ErrBlockchainFailure,
+		},
+		{
+			name: "malformed JSON",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, `{"responseData":`)
+			},
+			wantErr: auth.ErrBlockchainFailure,
+		},
+		{
+			name: "malformed response data",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				_, _ = io.WriteString(w, `{"responseData":"not base64!"}`)
+			},
+			wantErr: auth.ErrBlockchainFailure,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, tt.handler)
+
+			got, err := client.GetAuthStatus(context.Background(), testWallet)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetAuthStatus error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetAuthStatus: %v", err)
+			}
+
+			if got.WalletAddress != testWallet {
+				t.Errorf("WalletAddress = %q, want %q", got.WalletAddress, testWallet)
+			}
+			if got.ContractAddress != client.GetContractAddress() {
+				t.Errorf("ContractAddress = %q, want %q", got.ContractAddress, client.GetContractAddress())
+			}
+			if got.Status != tt.want.Status || got.TrustScore != tt.want.TrustScore || !got.UpdatedAt.Equal(tt.want.UpdatedAt) {
+				t.Errorf("GetAuthStatus = %s/%d/%v, want %s/%d/%v",
+					got.Status, got.TrustScore, got.UpdatedAt, tt.want.Status, tt.want.TrustScore, tt.want.UpdatedAt)
+			}
+		})
+	}
+}
+
+func TestGetAuthStatusNodeError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = io.WriteString(w, `{"code":3,"message":"contract not found"}`)
+	})
+
+	_, err := client.GetAuthStatus(context.Background(), testWallet)
+
+	var nodeErr *NodeError
+	if !errors.As(err, &nodeErr) {
+		t.Fatalf("GetAuthStatus error = %v, want a *NodeError", err)
+	}
+	if nodeErr.StatusCode != http.StatusBadRequest || nodeErr.Message != "contract not found" {
+		t.Errorf("NodeError = %d %q, want 400 %q", nodeErr.StatusCode, nodeErr.Message, "contract not found")
+	}
+}
+
+func TestGetAuthStatusRequest(t *testing.T) {
+	var (
+		method, path, contentType string
+		body                      querySmartContractRequest
+	)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		method, path, contentType = r.Method, r.URL.Path, r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decoding request body: %v", err)
+		}
+		respondWith(walletAuthData(1, 100, 0))(w, r)
+	})
+
+	if _, err := client.GetAuthStatus(context.Background(), testWallet); err != nil {
+		t.Fatalf("GetAuthStatus: %v", err)
+	}
+
+	if method != http.MethodPost || path != "/v1/querySmartContract" {
+		t.Errorf("request = %s %s, want POST /v1/querySmartContract", method, path)
+	}
+	if contentType != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", contentType)
+	}
+
+	publicKey, _ := identity.PublicKey(testWallet)
+	want := querySmartContractRequest{
+		ContractIndex: testContractIndex,
+		InputType:     getStatusFunction,
+		InputSize:     identity.PublicKeySize,
+		RequestData:   base64.StdEncoding.EncodeToString(publicKey[:]),
+	}
+	if body != want {
+		t.Errorf("request body = %+v, want %+v", body, want)
+	}
+}
+
+func TestGetTransactionState(t *testing.T) {
+	const txHash = "txhash"
+
+	tests := []struct {
+		name      string
+		submitted *submittedTx // nil if broadcast by another instance
+		included  bool
+		moneyFlew bool
+		tick      uint32
+		want      auth.TxState
+	}{
+		{
+			name:      "included",
+			submitted: &submittedTx{tick: 100},
+			included:  true,
+			want:      auth.TxConfirmed,
+		},
+		{
+			name:     "included, broadcast elsewhere",
+			included: true,
+			want:     auth.TxConfirmed,
+		},
+		{
+			name:      "included with amount transferred",
+			submitted: &submittedTx{tick: 100, amount: 10},
+			included:  true,
+			moneyFlew: true,
+			want:      auth.TxConfirmed,
+		},
+		{
+			name:      "included with amount not transferred",
+			submitted: &submittedTx{tick: 100, amount: 10},
+			included:  true,
+			want:      auth.TxFailed,
+		},
+		{
+			name:      "not included before target tick",
+			submitted: &submittedTx{tick: 100},
+			tick:      100 + txExpiryTicks,
+			want:      auth.TxPending,
+		},
+		{
+			name:      "not included past target tick",
+			submitted: &submittedTx{tick: 100},
+			tick:      101 + txExpiryTicks,
+			want:      auth.TxFailed,
+		},
+		{
+			name: "not included, broadcast elsewhere",
+			tick: 1000,
+			want: auth.TxPending,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/v1/tx-status/" + txHash:
+					if !tt.included {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = io.WriteString(w, `{"code":5,"message":"tx status not found"}`)
+						return
+					}
+					var resp txStatusResponse
+					resp.TransactionStatus.TxID = txHash
+					resp.TransactionStatus.MoneyFlew = tt.moneyFlew
+					_ = json.NewEncoder(w).Encode(resp)
+				case "/v1/tick-info":
+					var resp tickInfoResponse
+					resp.TickInfo.Tick = tt.tick
+					_ = json.NewEncoder(w).Encode(resp)
+				default:
+					http.NotFound(w, r)
+				}
+			})
+			if tt.submitted != nil {
+				client.submitted.Store(txHash, *tt.submitted)
+			}
+
+			got, err := client.GetTransactionState(context.Background(), txHash)
+			if err != nil {
+				t.Fatalf("GetTransactionState: %v", err)
+			}
+			if got.State != tt.want {
+				t.Errorf("GetTransactionState = %s (%s), want %s", got.State, got.Reason, tt.want)
+			}
+			if got.State == auth.TxFailed && got.Reason == "" {
+				t.Error("failed state has no reason")
+			}
+		})
+	}
+}
+
+func TestBatchGetAuthStatus(t *testing.T) {
+	const (
+		concurrency = 3
+		failing     = 2 // the node fails the query of this wallet
+		unknown     = 4 // the contract does not know this wallet
+		slow        = 6 // the node does not answer within the call timeout
+	)
+
+	var inFlight, maxInFlight atomic.Int32
+	client := newTestBatchClient(t, func(w http.ResponseWriter, r *http.Request) {
+		running := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			seen := maxInFlight.Load()
+			if running <= seen || maxInFlight.CompareAndSwap(seen, running) {
+				break
+			}
+		}
+
+		var req querySmartContractRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decoding request body: %v", err)
+			return
+		}
+		publicKey, _ := base64.StdEncoding.DecodeString(req.RequestData)
+
+		time.Sleep(20 * time.Millisecond)
+		switch index := publicKey[0]; index {
+		case failing:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case unknown:
+			respondWith(walletAuthData(0, 0, 0))(w, r)
+		case slow:
+			<-r.Context().Done()
+		default:
+			respondWith(walletAuthData(1, int32(index), 0))(w, r)
+		}
+	}, BatchConfig{Concurrency: concurrency, CallTimeout: 200 * time.Millisecond})
+
+	wallets := make([]string, 10)
+	for i := range wallets {
+		wallets[i] = identity.FromPublicKey([identity.PublicKeySize]byte{byte(i), 4, 5}, false)
+	}
+
+	results, err := client.BatchGetAuthStatus(context.Background(), wallets)
+	if err != nil {
+		t.Fatalf("BatchGetAuthStatus: %v", err)
+	}
+
+	if got := maxInFlight.Load(); got != concurrency {
+		t.Errorf("queries in flight = %d, want %d", got, concurrency)
+	}
+	if len(results) != len(wallets) {
+		t.Fatalf("BatchGetAuthStatus returned %d results, want %d", len(results), len(wallets))
+	}
+	for i, result := range results {
+		if result.WalletAddress != wallets[i] {
+			t.Errorf("result %d is for %s, want %s", i, result.WalletAddress, wallets[i])
+			continue
+		}
+		switch i {
+		case failing:
+			if !errors.Is(result.Err, auth.ErrBlockchainFailure) {
+				t.Errorf("failing wallet: error = %v, want %v", result.Err, auth.ErrBlockchainFailure)
+			}
+		case unknown:
+			if !errors.Is(result.Err, auth.ErrWalletNotFound) {
+				t.Errorf("unknown wallet: error = %v, want %v", result.Err, auth.ErrWalletNotFound)
+			}
+		case slow:
+			if result.Err == nil {
+				t.Error("slow wallet: want a timeout error")
+			}
+		default:
+			if result.Err != nil || result.Status.TrustScore != i {
+				t.Errorf("wallet %d: result = %+v, %v, want trust score %d", i, result.Status, result.Err, i)
+			}
+		}
+	}
+}
+
+func TestBatchGetAuthStatusCancelled(t *testing.T) {
+	client := newTestBatchClient(t, respondWith(walletAuthData(1, 50, 0)), BatchConfig{Concurrency: 2})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	wallets := []string{testWallet, testWallet, testWallet}
+	results, err := client.BatchGetAuthStatus(ctx, wallets)
+	if err != nil {
+		t.Fatalf("BatchGetAuthStatus: %v", err)
+	}
+	for i, result := range results {
+		if result.WalletAddress != wallets[i] || !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("result %d = %+v, want %s cancelled", i, result, wallets[i])
+		}
+	}
+}
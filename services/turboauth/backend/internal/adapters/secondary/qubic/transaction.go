@@ -0,0 +1,112 @@
+package qubic
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"turboauth/pkg/identity"
+	"turboauth/pkg/schnorrq"
+
+	"github.com/cloudflare/circl/xof/k12"
+)
+
+// seedLength is the number of lowercase letters in a Qubic seed
+const seedLength = 55
+
+// transactionHeaderSize is the size of a transaction without input and signature:
+// source (32), destination (32), amount (8), tick (4), inputType (2), inputSize (2)
+const transactionHeaderSize = 80
+
+// signer signs transactions on behalf of the admin identity
+type signer struct {
+	subseed   [32]byte
+	publicKey [identity.PublicKeySize]byte
+}
+
+// newSigner derives the admin keys from a 55-character lowercase seed
+func newSigner(seed string) (*signer, error) {
+	if len(seed) != seedLength {
+		return nil, fmt.Errorf("admin seed must be %d characters", seedLength)
+	}
+
+	seedBytes := make([]byte, seedLength)
+	for i := 0; i < seedLength; i++ {
+		if seed[i] < 'a' || seed[i] > 'z' {
+			return nil, fmt.Errorf("admin seed must contain only lowercase letters a-z")
+		}
+		seedBytes[i] = seed[i] - 'a'
+	}
+
+	s := &signer{}
+	copy(s.subseed[:], kangarooTwelve(seedBytes, 32))
+
+	var privateKey [32]byte
+	copy(privateKey[:], kangarooTwelve(s.subseed[:], 32))
+	s.publicKey = schnorrq.PublicKey(privateKey)
+
+	return s, nil
+}
+
+// identity returns the admin identity
+func (s *signer) identity() string {
+	return identity.FromPublicKey(s.publicKey, false)
+}
+
+// transaction is a Qubic transaction invoking a contract procedure
+type transaction struct {
+	sourcePublicKey      [identity.PublicKeySize]byte
+	destinationPublicKey [identity.PublicKeySize]byte
+	amount               int64
+	tick                 uint32
+	inputType            uint16
+	input                []byte
+	signature            [schnorrq.SignatureSize]byte
+}
+
+// unsignedBytes encodes the transaction without its signature
+func (tx *transaction) unsignedBytes() []byte {
+	buf := make([]byte, transactionHeaderSize, transactionHeaderSize+len(tx.input)+schnorrq.SignatureSize)
+	copy(buf[0:32], tx.sourcePublicKey[:])
+	copy(buf[32:64], tx.destinationPublicKey[:])
+	binary.LittleEndian.PutUint64(buf[64:72], uint64(tx.amount))
+	binary.LittleEndian.PutUint32(buf[72:76], tx.tick)
+	binary.LittleEndian.PutUint16(buf[76:78], tx.inputType)
+	binary.LittleEndian.PutUint16(buf[78:80], uint16(len(tx.input)))
+	return append(buf, tx.input...)
+}
+
+// sign signs the K12 digest of the unsigned transaction
+func (tx *transaction) sign(s *signer) {
+	var digest [schnorrq.DigestSize]byte
+	copy(digest[:], kangarooTwelve(tx.unsignedBytes(), schnorrq.DigestSize))
+	tx.signature = schnorrq.Sign(s.subseed, s.publicKey, digest)
+}
+
+// bytes encodes the signed transaction
+func (tx *transaction) bytes() []byte {
+	return append(tx.unsignedBytes(), tx.signature[:]...)
+}
+
+// id returns the transaction id: the K12 digest of the signed transaction,
+// encoded as a lowercase identity
+func (tx *transaction) id() string {
+	var digest [identity.PublicKeySize]byte
+	copy(digest[:], kangarooTwelve(tx.bytes(), identity.PublicKeySize))
+	return identity.FromPublicKey(digest, true)
+}
+
+// contractPublicKey returns the public key of the contract with the given index
+func contractPublicKey(index uint32) [identity.PublicKeySize]byte {
+	var publicKey [identity.PublicKeySize]byte
+	binary.LittleEndian.PutUint64(publicKey[0:8], uint64(index))
+	return publicKey
+}
+
+// kangarooTwelve computes a KangarooTwelve digest of the given size
+func kangarooTwelve(data []byte, size int) []byte {
+	h := k12.NewDraft10(nil)
+	_, _ = h.Write(data)
+	out := make([]byte, size)
+	_, _ = h.Read(out)
+	return out
+}
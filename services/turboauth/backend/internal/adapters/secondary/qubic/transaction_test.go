@@ -0,0 +1,78 @@
+package qubic
+
+import (
+	"strings"
+	"testing"
+
+	"turboauth/pkg/identity"
+	"turboauth/pkg/schnorrq"
+)
+
+func TestNewSigner(t *testing.T) {
+	tests := []struct {
+		name     string
+		seed     string
+		identity string
+		wantErr  bool
+	}{
+		{
+			name:     "known seed",
+			seed:     strings.Repeat("a", seedLength),
+			identity: "BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK",
+		},
+		{name: "too short", seed: strings.Repeat("a", seedLength-1), wantErr: true},
+		{name: "too long", seed: strings.Repeat("a", seedLength+1), wantErr: true},
+		{name: "uppercase", seed: strings.Repeat("A", seedLength), wantErr: true},
+		{name: "digit", seed: strings.Repeat("a", seedLength-1) + "1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := newSigner(tt.seed)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("newSigner(%q) succeeded, want an error", tt.seed)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newSigner: %v", err)
+			}
+			if got := s.identity(); got != tt.identity {
+				t.Errorf("identity = %s, want %s", got, tt.identity)
+			}
+		})
+	}
+}
+
+func TestTransactionSignature(t *testing.T) {
+	s, err := newSigner(strings.Repeat("a", seedLength))
+	if err != nil {
+		t.Fatalf("newSigner: %v", err)
+	}
+
+	tx := &transaction{
+		sourcePublicKey:      s.publicKey,
+		destinationPublicKey: contractPublicKey(testContractIndex),
+		tick:                 1000,
+		inputType:            setStatusProcedure,
+		input:                make([]byte, 40),
+	}
+	tx.sign(s)
+
+	encoded := tx.bytes()
+	if len(encoded) != transactionHeaderSize+len(tx.input)+schnorrq.SignatureSize {
+		t.Fatalf("encoded transaction is %d bytes", len(encoded))
+	}
+
+	var digest [schnorrq.DigestSize]byte
+	copy(digest[:], kangarooTwelve(tx.unsignedBytes(), schnorrq.DigestSize))
+	if !schnorrq.Verify(s.publicKey, digest, tx.signature) {
+		t.Error("transaction signature does not verify")
+	}
+
+	id := tx.id()
+	if id != strings.ToLower(id) || identity.Validate(strings.ToUpper(id)) != nil {
+		t.Errorf("transaction id %q is not a lowercase identity", id)
+	}
+}
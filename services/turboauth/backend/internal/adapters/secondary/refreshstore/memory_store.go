@@ -0,0 +1,105 @@
+package refreshstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"turboauth/internal/domain/auth"
+)
+
+// MemoryStore implements an in-memory refresh token family store (single instance only)
+type MemoryStore struct {
+	mu       sync.Mutex
+	families map[string]*auth.RefreshFamily
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewMemoryStore creates a new in-memory refresh token family store
+func NewMemoryStore() *MemoryStore {
+	store := &MemoryStore{
+		families: make(map[string]*auth.RefreshFamily),
+		stop:     make(chan struct{}),
+	}
+
+	// Start cleanup goroutine
+	go store.cleanupExpired()
+
+	return store
+}
+
+// CreateFamily stores a new refresh token family
+func (m *MemoryStore) CreateFamily(ctx context.Context, family *auth.RefreshFamily) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	copied := *family
+	m.families[family.FamilyID] = &copied
+	return nil
+}
+
+// GetFamily retrieves an unexpired family by ID
+func (m *MemoryStore) GetFamily(ctx context.Context, familyID string) (*auth.RefreshFamily, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.families[familyID]
+	if !ok || time.Now().After(stored.ExpiresAt) {
+		return nil, auth.ErrInvalidToken
+	}
+
+	family := *stored
+	return &family, nil
+}
+
+// UpdateFamily applies update to a stored family under the store lock
+func (m *MemoryStore) UpdateFamily(ctx context.Context, familyID string, update func(*auth.RefreshFamily) error) (*auth.RefreshFamily, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.families[familyID]
+	if !ok || time.Now().After(stored.ExpiresAt) {
+		return nil, auth.ErrInvalidToken
+	}
+
+	family := *stored
+	if err := update(&family); err != nil {
+		return nil, err
+	}
+	m.families[familyID] = &family
+
+	result := family
+	return &result, nil
+}
+
+// Stop ends the cleanup goroutine
+func (m *MemoryStore) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
+
+// cleanupExpired removes expired families periodically
+func (m *MemoryStore) cleanupExpired() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		m.mu.Lock()
+		for id, family := range m.families {
+			if now.After(family.ExpiresAt) {
+				delete(m.families, id)
+			}
+		}
+		m.mu.Unlock()
+	}
+}
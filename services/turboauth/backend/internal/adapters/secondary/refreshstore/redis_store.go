@@ -0,0 +1,130 @@
+package refreshstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/redisclient"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// maxUpdateRetries bounds optimistic-locking retries under contention
+const maxUpdateRetries = 10
+
+// RedisStore implements a Redis-based refresh token family store shared by all instances
+type RedisStore struct {
+	client redis.UniversalClient
+	keys   redisclient.Keyspace
+}
+
+// NewRedisStore creates a new Redis refresh token family store
+func NewRedisStore(opts redisclient.Options) (*RedisStore, error) {
+	client, err := redisclient.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisStore{client: client, keys: opts.Keyspace()}, nil
+}
+
+// CreateFamily stores a new refresh token family until it expires
+func (r *RedisStore) CreateFamily(ctx context.Context, family *auth.RefreshFamily) error {
+	data, err := json.Marshal(family)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(ctx, r.key(family.FamilyID), data, time.Until(family.ExpiresAt)).Err()
+}
+
+// GetFamily retrieves an unexpired family by ID
+func (r *RedisStore) GetFamily(ctx context.Context, familyID string) (*auth.RefreshFamily, error) {
+	data, err := r.client.Get(ctx, r.key(familyID)).Bytes()
+	if err == redis.Nil {
+		return nil, auth.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var family auth.RefreshFamily
+	if err := json.Unmarshal(data, &family); err != nil {
+		return nil, err
+	}
+	if time.Now().After(family.ExpiresAt) {
+		return nil, auth.ErrInvalidToken
+	}
+	return &family, nil
+}
+
+// UpdateFamily applies update to a stored family using optimistic locking,
+// retrying if another instance changed it concurrently
+// Two concurrent uses of the same refresh token therefore cannot both rotate it.
+func (r *RedisStore) UpdateFamily(ctx context.Context, familyID string, update func(*auth.RefreshFamily) error) (*auth.RefreshFamily, error) {
+	key := r.key(familyID)
+
+	var result *auth.RefreshFamily
+	txf := func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return auth.ErrInvalidToken
+		}
+		if err != nil {
+			return err
+		}
+
+		var family auth.RefreshFamily
+		if err := json.Unmarshal(data, &family); err != nil {
+			return err
+		}
+		if err := update(&family); err != nil {
+			return err
+		}
+
+		ttl := time.Until(family.ExpiresAt)
+		if ttl <= 0 {
+			return auth.ErrInvalidToken
+		}
+
+		data, err = json.Marshal(&family)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, ttl)
+			return nil
+		})
+		if err == nil {
+			result = &family
+		}
+		return err
+	}
+
+	for i := 0; i < maxUpdateRetries; i++ {
+		err := r.client.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("refresh token family %s: too much contention", familyID)
+}
+
+// Close closes the Redis connection
+func (r *RedisStore) Close() error {
+	return r.client.Close()
+}
+
+func (r *RedisStore) key(familyID string) string {
+	return r.keys.Key("refresh_family", familyID)
+}
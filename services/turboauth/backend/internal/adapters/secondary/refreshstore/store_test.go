@@ -0,0 +1,205 @@
+package refreshstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/redisclient"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// errStale is returned by rotate when the family has moved on
+var errStale = errors.New("stale token")
+
+// testStore is a family store under test, with a way to let its entries age
+type testStore struct {
+	name    string
+	store   auth.RefreshTokenPort
+	advance func(d time.Duration)
+}
+
+// testStores returns a memory store and a store backed by an in-process Redis server
+func testStores(t *testing.T) []testStore {
+	t.Helper()
+
+	memory := NewMemoryStore()
+	t.Cleanup(memory.Stop)
+
+	server := miniredis.RunT(t)
+	redisStore, err := NewRedisStore(redisclient.Options{Addrs: []string{server.Addr()}})
+	if err != nil {
+		t.Fatalf("NewRedisStore: %v", err)
+	}
+	t.Cleanup(func() { redisStore.Close() })
+
+	return []testStore{
+		{name: "memory", store: memory, advance: time.Sleep},
+		{name: "redis", store: redisStore, advance: server.FastForward},
+	}
+}
+
+// createFamily stores a family whose current token hash is "hash-0"
+func createFamily(t *testing.T, store auth.RefreshTokenPort, familyID string, lifetime time.Duration) {
+	t.Helper()
+
+	err := store.CreateFamily(context.Background(), &auth.RefreshFamily{
+		FamilyID:      familyID,
+		SessionID:     "session",
+		WalletAddress: "wallet",
+		TokenHash:     "hash-0",
+		Lifetime:      lifetime,
+		ExpiresAt:     time.Now().Add(lifetime),
+	})
+	if err != nil {
+		t.Fatalf("CreateFamily: %v", err)
+	}
+}
+
+// rotate replaces the token hash of a family if it is still from, the way the
+// service rotates a presented token
+func rotate(from, to string) func(*auth.RefreshFamily) error {
+	return func(family *auth.RefreshFamily) error {
+		if family.Revoked || family.TokenHash != from {
+			return errStale
+		}
+		family.TokenHash = to
+		family.Generation++
+		return nil
+	}
+}
+
+func TestConcurrentRotationsHaveOneWinner(t *testing.T) {
+	const rounds = 20
+
+	for _, ts := range testStores(t) {
+		t.Run(ts.name, func(t *testing.T) {
+			store := ts.store
+			ctx := context.Background()
+			createFamily(t, store, "family", time.Hour)
+
+			for round := 0; round < rounds; round++ {
+				current := fmt.Sprintf("hash-%d", round)
+				next := fmt.Sprintf("hash-%d", round+1)
+
+				var wg sync.WaitGroup
+				errs := make([]error, 2)
+				for i := range errs {
+					wg.Add(1)
+					go func(i int) {
+						defer wg.Done()
+						_, errs[i] = store.UpdateFamily(ctx, "family", rotate(current, next))
+					}(i)
+				}
+				wg.Wait()
+
+				won := 0
+				for _, err := range errs {
+					switch {
+					case err == nil:
+						won++
+					case !errors.Is(err, errStale):
+						t.Fatalf("round %d: UpdateFamily: %v", round, err)
+					}
+				}
+				if won != 1 {
+					t.Fatalf("round %d: %d rotations won, want exactly 1", round, won)
+				}
+
+				family, err := store.GetFamily(ctx, "family")
+				if err != nil {
+					t.Fatalf("GetFamily: %v", err)
+				}
+				if family.TokenHash != next || family.Generation != round+1 {
+					t.Fatalf("round %d: family = hash %q generation %d, want %q and %d",
+						round, family.TokenHash, family.Generation, next, round+1)
+				}
+			}
+		})
+	}
+}
+
+func TestRevokedFamilyStaysRevoked(t *testing.T) {
+	for _, ts := range testStores(t) {
+		t.Run(ts.name, func(t *testing.T) {
+			store := ts.store
+			ctx := context.Background()
+			createFamily(t, store, "family", time.Hour)
+
+			// A reused token revokes the family without failing the update
+			revoke := func(family *auth.RefreshFamily) error {
+				family.Revoked = true
+				return nil
+			}
+			if _, err := store.UpdateFamily(ctx, "family", revoke); err != nil {
+				t.Fatalf("UpdateFamily: %v", err)
+			}
+
+			family, err := store.GetFamily(ctx, "family")
+			if err != nil {
+				t.Fatalf("GetFamily: %v", err)
+			}
+			if !family.Revoked {
+				t.Fatal("family not revoked after the update")
+			}
+
+			// Not even the current token rotates a revoked family
+			if _, err := store.UpdateFamily(ctx, "family", rotate("hash-0", "hash-1")); !errors.Is(err, errStale) {
+				t.Errorf("rotating a revoked family: error = %v, want %v", err, errStale)
+			}
+
+			// A failed update stores nothing
+			family, err = store.GetFamily(ctx, "family")
+			if err != nil {
+				t.Fatalf("GetFamily: %v", err)
+			}
+			if !family.Revoked || family.TokenHash != "hash-0" {
+				t.Errorf("family after a failed update = revoked %v, hash %q, want revoked and unchanged", family.Revoked, family.TokenHash)
+			}
+		})
+	}
+}
+
+func TestFamiliesExpire(t *testing.T) {
+	const lifetime = 100 * time.Millisecond
+
+	for _, ts := range testStores(t) {
+		t.Run(ts.name, func(t *testing.T) {
+			store := ts.store
+			ctx := context.Background()
+
+			if _, err := store.GetFamily(ctx, "unknown"); !errors.Is(err, auth.ErrInvalidToken) {
+				t.Errorf("GetFamily of an unknown family: error = %v, want %v", err, auth.ErrInvalidToken)
+			}
+
+			createFamily(t, store, "expiring", lifetime)
+			createFamily(t, store, "extended", lifetime)
+
+			// Rotating moves the expiry, and the store keeps the family until then
+			_, err := store.UpdateFamily(ctx, "extended", func(family *auth.RefreshFamily) error {
+				family.ExpiresAt = time.Now().Add(time.Hour)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("UpdateFamily: %v", err)
+			}
+
+			ts.advance(lifetime + 10*time.Millisecond)
+
+			if _, err := store.GetFamily(ctx, "expiring"); !errors.Is(err, auth.ErrInvalidToken) {
+				t.Errorf("GetFamily after expiry: error = %v, want %v", err, auth.ErrInvalidToken)
+			}
+			if _, err := store.UpdateFamily(ctx, "expiring", rotate("hash-0", "hash-1")); !errors.Is(err, auth.ErrInvalidToken) {
+				t.Errorf("UpdateFamily after expiry: error = %v, want %v", err, auth.ErrInvalidToken)
+			}
+			if _, err := store.GetFamily(ctx, "extended"); err != nil {
+				t.Errorf("GetFamily of an extended family: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"turboauth/internal/domain/auth"
+)
+
+// entry is a revocation kept until expiresAt
+type entry struct {
+	revokedAt time.Time
+	expiresAt time.Time
+}
+
+// MemoryStore implements an in-memory revocation list (single instance only)
+type MemoryStore struct {
+	mu       sync.Mutex
+	tokens   map[string]entry
+	sessions map[string]entry
+	wallets  map[string]entry
+	blocked  map[string]entry
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewMemoryStore creates a new in-memory revocation list
+func NewMemoryStore() *MemoryStore {
+	store := &MemoryStore{
+		tokens:   make(map[string]entry),
+		sessions: make(map[string]entry),
+		wallets:  make(map[string]entry),
+		blocked:  make(map[string]entry),
+		stop:     make(chan struct{}),
+	}
+
+	// Start cleanup goroutine
+	go store.cleanupExpired()
+
+	return store
+}
+
+// RevokeToken revokes a single token until expiresAt
+func (m *MemoryStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.revoke(m.tokens, tokenID, expiresAt)
+	return nil
+}
+
+// RevokeSession revokes every token of a session until expiresAt
+func (m *MemoryStore) RevokeSession(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	m.revoke(m.sessions, sessionID, expiresAt)
+	return nil
+}
+
+// RevokeWallet revokes every token issued for a wallet up to now, until expiresAt
+func (m *MemoryStore) RevokeWallet(ctx context.Context, walletAddress string, expiresAt time.Time) error {
+	m.revoke(m.wallets, walletAddress, expiresAt)
+	return nil
+}
+
+// IsRevoked reports whether a token has been revoked by ID, session or wallet
+func (m *MemoryStore) IsRevoked(ctx context.Context, claims *auth.TokenClaims) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if e, ok := m.tokens[claims.TokenID]; ok && now.Before(e.expiresAt) {
+		return true, nil
+	}
+	if e, ok := m.sessions[claims.SessionID]; ok && now.Before(e.expiresAt) {
+		return true, nil
+	}
+	if e, ok := m.wallets[claims.WalletAddress]; ok && now.Before(e.expiresAt) {
+		return issuedBefore(claims, e.revokedAt.Unix()), nil
+	}
+	return false, nil
+}
+
+// BlockWallet marks a wallet blocked from now until expiresAt, or until it is unblocked
+func (m *MemoryStore) BlockWallet(ctx context.Context, walletAddress string, expiresAt time.Time) error {
+	m.revoke(m.blocked, walletAddress, expiresAt)
+	return nil
+}
+
+// UnblockWallet removes the blocked marker of a wallet
+func (m *MemoryStore) UnblockWallet(ctx context.Context, walletAddress string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.blocked, walletAddress)
+	return nil
+}
+
+// BlockedSince returns when a wallet was marked blocked, or the zero time if it is not
+func (m *MemoryStore) BlockedSince(ctx context.Context, walletAddress string) (time.Time, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if e, ok := m.blocked[walletAddress]; ok && time.Now().Before(e.expiresAt) {
+		return e.revokedAt, nil
+	}
+	return time.Time{}, nil
+}
+
+// Stop ends the cleanup goroutine
+func (m *MemoryStore) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
+
+// revoke records a revocation, keeping the longest expiry of repeated ones
+func (m *MemoryStore) revoke(entries map[string]entry, id string, expiresAt time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := entries[id]; ok && existing.expiresAt.After(expiresAt) {
+		expiresAt = existing.expiresAt
+	}
+	entries[id] = entry{revokedAt: time.Now(), expiresAt: expiresAt}
+}
+
+// cleanupExpired removes expired revocations periodically
+func (m *MemoryStore) cleanupExpired() {
+	ticker := time.NewTicker(1 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		m.mu.Lock()
+		for _, entries := range []map[string]entry{m.tokens, m.sessions, m.wallets, m.blocked} {
+			for id, e := range entries {
+				if now.After(e.expiresAt) {
+					delete(entries, id)
+				}
+			}
+		}
+		m.mu.Unlock()
+	}
+}
+
+// issuedBefore reports whether a token was issued no later than the second
+// of a wallet revocation
+// Tokens carry their issue time in whole seconds, so a token issued in the
+// same second as the revocation is treated as revoked too.
+func issuedBefore(claims *auth.TokenClaims, revokedAt int64) bool {
+	return claims.IssuedAt.Unix() <= revokedAt
+}
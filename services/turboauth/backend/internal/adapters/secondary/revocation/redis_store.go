@@ -0,0 +1,110 @@
+package revocation
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/redisclient"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore implements a Redis-based revocation list shared by all instances
+// Revoked token and session IDs are stored as flags, revoked wallets as the
+// Unix second of their revocation; every entry expires with the tokens it covers.
+// Blocked wallets are stored as the Unix second they were blocked.
+type RedisStore struct {
+	client redis.UniversalClient
+	keys   redisclient.Keyspace
+}
+
+// NewRedisStore creates a new Redis revocation list
+func NewRedisStore(opts redisclient.Options) (*RedisStore, error) {
+	client, err := redisclient.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisStore{client: client, keys: opts.Keyspace()}, nil
+}
+
+// RevokeToken revokes a single token until expiresAt
+func (r *RedisStore) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	return r.revoke(ctx, r.keys.Key("revoked_token", tokenID), 1, expiresAt)
+}
+
+// RevokeSession revokes every token of a session until expiresAt
+func (r *RedisStore) RevokeSession(ctx context.Context, sessionID string, expiresAt time.Time) error {
+	return r.revoke(ctx, r.keys.Key("revoked_session", sessionID), 1, expiresAt)
+}
+
+// RevokeWallet revokes every token issued for a wallet up to now, until expiresAt
+// A later revocation replaces an earlier one: it covers every token the
+// earlier one did, and its expiry is computed from a later time.
+func (r *RedisStore) RevokeWallet(ctx context.Context, walletAddress string, expiresAt time.Time) error {
+	return r.revoke(ctx, r.keys.Key("revoked_wallet", walletAddress), time.Now().Unix(), expiresAt)
+}
+
+// IsRevoked reports whether a token has been revoked by ID, session or wallet
+func (r *RedisStore) IsRevoked(ctx context.Context, claims *auth.TokenClaims) (bool, error) {
+	var token, session, wallet *redis.StringCmd
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		token = pipe.Get(ctx, r.keys.Key("revoked_token", claims.TokenID))
+		session = pipe.Get(ctx, r.keys.Key("revoked_session", claims.SessionID))
+		wallet = pipe.Get(ctx, r.keys.Key("revoked_wallet", claims.WalletAddress))
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return false, err
+	}
+
+	if token.Err() == nil || session.Err() == nil {
+		return true, nil
+	}
+	if wallet.Err() == redis.Nil {
+		return false, nil
+	}
+
+	revokedAt, err := strconv.ParseInt(wallet.Val(), 10, 64)
+	if err != nil {
+		return false, err
+	}
+	return issuedBefore(claims, revokedAt), nil
+}
+
+// BlockWallet marks a wallet blocked from now until expiresAt, or until it is unblocked
+func (r *RedisStore) BlockWallet(ctx context.Context, walletAddress string, expiresAt time.Time) error {
+	return r.revoke(ctx, r.keys.Key("blocked_wallet", walletAddress), time.Now().Unix(), expiresAt)
+}
+
+// UnblockWallet removes the blocked marker of a wallet
+func (r *RedisStore) UnblockWallet(ctx context.Context, walletAddress string) error {
+	return r.client.Del(ctx, r.keys.Key("blocked_wallet", walletAddress)).Err()
+}
+
+// BlockedSince returns when a wallet was marked blocked, or the zero time if it is not
+func (r *RedisStore) BlockedSince(ctx context.Context, walletAddress string) (time.Time, error) {
+	since, err := r.client.Get(ctx, r.keys.Key("blocked_wallet", walletAddress)).Int64()
+	if err == redis.Nil {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(since, 0), nil
+}
+
+// Close closes the Redis connection
+func (r *RedisStore) Close() error {
+	return r.client.Close()
+}
+
+func (r *RedisStore) revoke(ctx context.Context, key string, value any, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil // the tokens it covers have expired already
+	}
+	return r.client.Set(ctx, key, value, ttl).Err()
+}
@@ -0,0 +1,272 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/redisclient"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// testStore is a revocation list under test, with a way to let its entries age
+type testStore struct {
+	name    string
+	store   auth.RevocationPort
+	advance func(d time.Duration)
+}
+
+// testStores returns a memory store and a store backed by an in-process Redis server
+func testStores(t *testing.T) []testStore {
+	t.Helper()
+
+	memory := NewMemoryStore()
+	t.Cleanup(memory.Stop)
+
+	server := miniredis.RunT(t)
+	redisStore, err := NewRedisStore(redisclient.Options{Addrs: []string{server.Addr()}})
+	if err != nil {
+		t.Fatalf("NewRedisStore: %v", err)
+	}
+	t.Cleanup(func() { redisStore.Close() })
+
+	return []testStore{
+		{name: "memory", store: memory, advance: time.Sleep},
+		{name: "redis", store: redisStore, advance: server.FastForward},
+	}
+}
+
+// testClaims returns the claims of a token issued at issuedAt
+func testClaims(tokenID, sessionID, walletAddress string, issuedAt time.Time) *auth.TokenClaims {
+	return &auth.TokenClaims{
+		TokenID:       tokenID,
+		WalletAddress: walletAddress,
+		SessionID:     sessionID,
+		IssuedAt:      issuedAt,
+		ExpiresAt:     issuedAt.Add(time.Hour),
+	}
+}
+
+// assertRevoked checks whether store reports claims revoked
+func assertRevoked(t *testing.T, store auth.RevocationPort, claims *auth.TokenClaims, want bool) {
+	t.Helper()
+
+	revoked, err := store.IsRevoked(context.Background(), claims)
+	if err != nil {
+		t.Fatalf("IsRevoked: %v", err)
+	}
+	if revoked != want {
+		t.Errorf("IsRevoked(token %q, session %q, wallet %q, issued %v) = %v, want %v",
+			claims.TokenID, claims.SessionID, claims.WalletAddress, claims.IssuedAt.Unix(), revoked, want)
+	}
+}
+
+// revokeWallet revokes a wallet and returns the second the revocation was
+// recorded in, retrying if the clock ticked over during the call
+func revokeWallet(t *testing.T, store auth.RevocationPort, walletAddress string) int64 {
+	t.Helper()
+
+	for {
+		before := time.Now().Unix()
+		if err := store.RevokeWallet(context.Background(), walletAddress, time.Now().Add(time.Hour)); err != nil {
+			t.Fatalf("RevokeWallet: %v", err)
+		}
+		if time.Now().Unix() == before {
+			return before
+		}
+	}
+}
+
+func TestRevocationKinds(t *testing.T) {
+	for _, ts := range testStores(t) {
+		t.Run(ts.name, func(t *testing.T) {
+			store := ts.store
+			ctx := context.Background()
+			expiresAt := time.Now().Add(time.Hour)
+			issuedAt := time.Now()
+
+			if err := store.RevokeToken(ctx, "revoked-token", expiresAt); err != nil {
+				t.Fatalf("RevokeToken: %v", err)
+			}
+			if err := store.RevokeSession(ctx, "revoked-session", expiresAt); err != nil {
+				t.Fatalf("RevokeSession: %v", err)
+			}
+
+			tests := []struct {
+				name   string
+				claims *auth.TokenClaims
+				want   bool
+			}{
+				{name: "revoked token", claims: testClaims("revoked-token", "session", "wallet", issuedAt), want: true},
+				{name: "other token of the session", claims: testClaims("token", "session", "wallet", issuedAt), want: false},
+				{name: "token of a revoked session", claims: testClaims("token", "revoked-session", "wallet", issuedAt), want: true},
+				{name: "unrelated token", claims: testClaims("other-token", "other-session", "other-wallet", issuedAt), want: false},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					assertRevoked(t, store, tt.claims, tt.want)
+				})
+			}
+		})
+	}
+}
+
+func TestRevokeWalletCoversTokensIssuedUpToItsSecond(t *testing.T) {
+	for _, ts := range testStores(t) {
+		t.Run(ts.name, func(t *testing.T) {
+			store := ts.store
+			revokedAt := revokeWallet(t, store, "wallet")
+
+			tests := []struct {
+				name     string
+				wallet   string
+				issuedAt time.Time
+				want     bool
+			}{
+				{name: "issued a second before", wallet: "wallet", issuedAt: time.Unix(revokedAt-1, 0), want: true},
+				{name: "issued in the same second", wallet: "wallet", issuedAt: time.Unix(revokedAt, 999_000_000), want: true},
+				{name: "issued a second after", wallet: "wallet", issuedAt: time.Unix(revokedAt+1, 0), want: false},
+				{name: "other wallet", wallet: "other-wallet", issuedAt: time.Unix(revokedAt, 0), want: false},
+			}
+			for _, tt := range tests {
+				t.Run(tt.name, func(t *testing.T) {
+					assertRevoked(t, store, testClaims("token", "session", tt.wallet, tt.issuedAt), tt.want)
+				})
+			}
+		})
+	}
+}
+
+func TestBlockWallet(t *testing.T) {
+	for _, ts := range testStores(t) {
+		t.Run(ts.name, func(t *testing.T) {
+			store := ts.store
+			ctx := context.Background()
+
+			blockedSince := func() time.Time {
+				t.Helper()
+				since, err := store.BlockedSince(ctx, "wallet")
+				if err != nil {
+					t.Fatalf("BlockedSince: %v", err)
+				}
+				return since
+			}
+
+			if since := blockedSince(); !since.IsZero() {
+				t.Fatalf("BlockedSince before blocking = %v, want zero", since)
+			}
+
+			before := time.Now().Unix()
+			if err := store.BlockWallet(ctx, "wallet", time.Now().Add(time.Hour)); err != nil {
+				t.Fatalf("BlockWallet: %v", err)
+			}
+			after := time.Now().Unix()
+			if since := blockedSince().Unix(); since < before || since > after {
+				t.Errorf("BlockedSince = %d, want within [%d, %d]", since, before, after)
+			}
+
+			// Blocking is not a revocation of the wallet's tokens
+			assertRevoked(t, store, testClaims("token", "session", "wallet", time.Unix(before-1, 0)), false)
+
+			if err := store.UnblockWallet(ctx, "wallet"); err != nil {
+				t.Fatalf("UnblockWallet: %v", err)
+			}
+			if since := blockedSince(); !since.IsZero() {
+				t.Errorf("BlockedSince after unblocking = %v, want zero", since)
+			}
+			if err := store.UnblockWallet(ctx, "wallet"); err != nil {
+				t.Errorf("UnblockWallet of an unblocked wallet: %v", err)
+			}
+		})
+	}
+}
+
+func TestRevocationsExpire(t *testing.T) {
+	const lifetime = 100 * time.Millisecond
+
+	for _, ts := range testStores(t) {
+		t.Run(ts.name, func(t *testing.T) {
+			store := ts.store
+			ctx := context.Background()
+			issuedAt := time.Now().Add(-time.Minute)
+
+			// Revocations that end now or earlier cover no live token
+			now := time.Now()
+			if err := store.RevokeToken(ctx, "token", now); err != nil {
+				t.Fatalf("RevokeToken: %v", err)
+			}
+			if err := store.BlockWallet(ctx, "wallet", now.Add(-time.Second)); err != nil {
+				t.Fatalf("BlockWallet: %v", err)
+			}
+			assertRevoked(t, store, testClaims("token", "session", "wallet", issuedAt), false)
+			if since, err := store.BlockedSince(ctx, "wallet"); err != nil || !since.IsZero() {
+				t.Errorf("BlockedSince after an expired block = %v, %v, want zero", since, err)
+			}
+
+			expiresAt := time.Now().Add(lifetime)
+			if err := store.RevokeSession(ctx, "session", expiresAt); err != nil {
+				t.Fatalf("RevokeSession: %v", err)
+			}
+			if err := store.BlockWallet(ctx, "wallet", expiresAt); err != nil {
+				t.Fatalf("BlockWallet: %v", err)
+			}
+			assertRevoked(t, store, testClaims("token", "session", "wallet", issuedAt), true)
+
+			ts.advance(lifetime + 10*time.Millisecond)
+
+			assertRevoked(t, store, testClaims("token", "session", "wallet", issuedAt), false)
+			if since, err := store.BlockedSince(ctx, "wallet"); err != nil || !since.IsZero() {
+				t.Errorf("BlockedSince after the block expired = %v, %v, want zero", since, err)
+			}
+		})
+	}
+}
+
+func TestRedisIsRevokedWithSomeKeysMissing(t *testing.T) {
+	server := miniredis.RunT(t)
+	store, err := NewRedisStore(redisclient.Options{Addrs: []string{server.Addr()}})
+	if err != nil {
+		t.Fatalf("NewRedisStore: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	ctx := context.Background()
+	expiresAt := time.Now().Add(time.Hour)
+	if err := store.RevokeToken(ctx, "revoked-token", expiresAt); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+	if err := store.RevokeSession(ctx, "revoked-session", expiresAt); err != nil {
+		t.Fatalf("RevokeSession: %v", err)
+	}
+	revokedAt := revokeWallet(t, store, "revoked-wallet")
+
+	// The pipeline reads the token, session and wallet keys in that order;
+	// each case leaves a different subset of them missing
+	tests := []struct {
+		name   string
+		claims *auth.TokenClaims
+		want   bool
+	}{
+		{name: "all missing", claims: testClaims("token", "session", "wallet", time.Unix(revokedAt, 0)), want: false},
+		{name: "only token", claims: testClaims("revoked-token", "session", "wallet", time.Unix(revokedAt, 0)), want: true},
+		{name: "only session", claims: testClaims("token", "revoked-session", "wallet", time.Unix(revokedAt, 0)), want: true},
+		{name: "only wallet, covered", claims: testClaims("token", "session", "revoked-wallet", time.Unix(revokedAt, 0)), want: true},
+		{name: "only wallet, issued later", claims: testClaims("token", "session", "revoked-wallet", time.Unix(revokedAt+1, 0)), want: false},
+		{name: "token and wallet", claims: testClaims("revoked-token", "session", "revoked-wallet", time.Unix(revokedAt+1, 0)), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRevoked(t, store, tt.claims, tt.want)
+		})
+	}
+
+	// A malformed wallet entry is an error, not a verdict
+	if err := server.Set(store.keys.Key("revoked_wallet", "corrupt-wallet"), "not a number"); err != nil {
+		t.Fatalf("seeding: %v", err)
+	}
+	if _, err := store.IsRevoked(ctx, testClaims("token", "session", "corrupt-wallet", time.Now())); err == nil {
+		t.Error("IsRevoked with a malformed wallet entry: want an error")
+	}
+}
@@ -0,0 +1,155 @@
+package sessionstore
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"turboauth/internal/domain/auth"
+)
+
+// MemoryStore implements an in-memory session store (single instance only)
+// Expired sessions are removed by CleanupExpiredSessions.
+type MemoryStore struct {
+	mu       sync.Mutex
+	sessions map[string]*auth.Session
+	wallets  map[string]map[string]struct{} // wallet -> session IDs
+}
+
+// NewMemoryStore creates a new in-memory session store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		sessions: make(map[string]*auth.Session),
+		wallets:  make(map[string]map[string]struct{}),
+	}
+}
+
+// CreateSession stores a new session
+func (m *MemoryStore) CreateSession(ctx context.Context, session *auth.Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	copied := *session
+	m.sessions[session.SessionID] = &copied
+
+	ids, ok := m.wallets[session.WalletAddress]
+	if !ok {
+		ids = make(map[string]struct{})
+		m.wallets[session.WalletAddress] = ids
+	}
+	ids[session.SessionID] = struct{}{}
+	return nil
+}
+
+// GetSession retrieves a session by ID
+func (m *MemoryStore) GetSession(ctx context.Context, sessionID string) (*auth.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, auth.ErrSessionNotFound
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, auth.ErrSessionExpired
+	}
+
+	copied := *session
+	return &copied, nil
+}
+
+// RefreshSession extends an unexpired session by its original lifetime
+func (m *MemoryStore) RefreshSession(ctx context.Context, sessionID string) (*auth.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[sessionID]
+	if !ok {
+		return nil, auth.ErrSessionNotFound
+	}
+
+	now := time.Now()
+	if now.After(session.ExpiresAt) {
+		return nil, auth.ErrSessionExpired
+	}
+	refresh(session, now)
+
+	copied := *session
+	return &copied, nil
+}
+
+// DeleteSession removes a session
+func (m *MemoryStore) DeleteSession(ctx context.Context, sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[sessionID]
+	if !ok {
+		return auth.ErrSessionNotFound
+	}
+	m.remove(session)
+	return nil
+}
+
+// GetActiveSessions returns the unexpired sessions of a wallet, oldest first
+func (m *MemoryStore) GetActiveSessions(ctx context.Context, walletAddress string) ([]*auth.Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	sessions := make([]*auth.Session, 0, len(m.wallets[walletAddress]))
+	for id := range m.wallets[walletAddress] {
+		session := m.sessions[id]
+		if now.After(session.ExpiresAt) {
+			continue
+		}
+		copied := *session
+		sessions = append(sessions, &copied)
+	}
+
+	sortByCreation(sessions)
+	return sessions, nil
+}
+
+// CleanupExpiredSessions removes every expired session
+func (m *MemoryStore) CleanupExpiredSessions(ctx context.Context) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	removed := 0
+	for _, session := range m.sessions {
+		if now.After(session.ExpiresAt) {
+			m.remove(session)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// remove deletes a session and its wallet index entry; the caller holds the lock
+func (m *MemoryStore) remove(session *auth.Session) {
+	delete(m.sessions, session.SessionID)
+
+	ids := m.wallets[session.WalletAddress]
+	delete(ids, session.SessionID)
+	if len(ids) == 0 {
+		delete(m.wallets, session.WalletAddress)
+	}
+}
+
+// refresh moves the expiry of session to its lifetime after now
+// The lifetime is the time between the last activity and the expiry, which
+// creating and refreshing a session both keep equal to the requested TTL.
+func refresh(session *auth.Session, now time.Time) {
+	lifetime := session.ExpiresAt.Sub(session.LastActivity)
+	session.LastActivity = now
+	session.ExpiresAt = now.Add(lifetime)
+}
+
+func sortByCreation(sessions []*auth.Session) {
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].CreatedAt.Before(sessions[j].CreatedAt)
+	})
+}
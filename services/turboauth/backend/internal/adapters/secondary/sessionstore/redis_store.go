@@ -0,0 +1,257 @@
+package sessionstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/redisclient"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// maxRefreshRetries bounds optimistic-locking retries under contention
+	maxRefreshRetries = 10
+
+	// scanCount is the number of keys SCAN is asked to inspect per call
+	scanCount = 1000
+)
+
+// RedisStore implements a Redis-based session store shared by all instances
+//
+// Each session is stored under its ID until it expires. A sorted set per
+// wallet indexes its session IDs by expiry (Unix milliseconds), so active
+// sessions can be listed without scanning; expired index entries are pruned
+// by CleanupExpiredSessions.
+type RedisStore struct {
+	client redis.UniversalClient
+	keys   redisclient.Keyspace
+}
+
+// NewRedisStore creates a new Redis session store
+func NewRedisStore(opts redisclient.Options) (*RedisStore, error) {
+	client, err := redisclient.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisStore{client: client, keys: opts.Keyspace()}, nil
+}
+
+// CreateSession stores a new session until it expires
+func (r *RedisStore) CreateSession(ctx context.Context, session *auth.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, r.key(session.SessionID), data, time.Until(session.ExpiresAt))
+		pipe.ZAdd(ctx, r.walletKey(session.WalletAddress), indexEntry(session))
+		return nil
+	})
+	return err
+}
+
+// GetSession retrieves a session by ID
+func (r *RedisStore) GetSession(ctx context.Context, sessionID string) (*auth.Session, error) {
+	session, err := r.get(ctx, r.client, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, auth.ErrSessionExpired
+	}
+	return session, nil
+}
+
+// RefreshSession extends an unexpired session by its original lifetime, using
+// optimistic locking so concurrent refreshes from other instances are not lost
+func (r *RedisStore) RefreshSession(ctx context.Context, sessionID string) (*auth.Session, error) {
+	key := r.key(sessionID)
+
+	var result *auth.Session
+	txf := func(tx *redis.Tx) error {
+		session, err := r.get(ctx, tx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if now.After(session.ExpiresAt) {
+			return auth.ErrSessionExpired
+		}
+		refresh(session, now)
+
+		data, err := json.Marshal(session)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, time.Until(session.ExpiresAt))
+			return nil
+		})
+		if err == nil {
+			result = session
+		}
+		return err
+	}
+
+	for i := 0; i < maxRefreshRetries; i++ {
+		err := r.client.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// The wallet index lives in another slot in cluster mode, so it is
+		// updated outside the transaction; a stale score only delays pruning
+		if err := r.client.ZAdd(ctx, r.walletKey(result.WalletAddress), indexEntry(result)).Err(); err != nil {
+			return nil, err
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("session %s: too much contention", sessionID)
+}
+
+// DeleteSession removes a session and its wallet index entry
+func (r *RedisStore) DeleteSession(ctx context.Context, sessionID string) error {
+	session, err := r.get(ctx, r.client, sessionID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, r.key(sessionID))
+		pipe.ZRem(ctx, r.walletKey(session.WalletAddress), sessionID)
+		return nil
+	})
+	return err
+}
+
+// GetActiveSessions returns the unexpired sessions of a wallet, oldest first
+func (r *RedisStore) GetActiveSessions(ctx context.Context, walletAddress string) ([]*auth.Session, error) {
+	now := time.Now()
+	ids, err := r.client.ZRangeByScore(ctx, r.walletKey(walletAddress), &redis.ZRangeBy{
+		Min: "(" + strconv.FormatInt(now.UnixMilli(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []*auth.Session{}, nil
+	}
+
+	// Pipelined GETs rather than MGET: in cluster mode the sessions live in
+	// different slots, and the pipeline is split by node
+	cmds := make([]*redis.StringCmd, len(ids))
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, id := range ids {
+			cmds[i] = pipe.Get(ctx, r.key(id))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	sessions := make([]*auth.Session, 0, len(cmds))
+	for _, cmd := range cmds {
+		data, err := cmd.Bytes()
+		if err != nil {
+			continue // deleted since the index was read
+		}
+
+		var session auth.Session
+		if err := json.Unmarshal(data, &session); err != nil {
+			continue
+		}
+		if now.After(session.ExpiresAt) {
+			continue
+		}
+		sessions = append(sessions, &session)
+	}
+
+	sortByCreation(sessions)
+	return sessions, nil
+}
+
+// CleanupExpiredSessions prunes expired sessions from the wallet indexes
+// The sessions themselves are expired by Redis.
+func (r *RedisStore) CleanupExpiredSessions(ctx context.Context) (int, error) {
+	pattern := r.keys.Pattern("wallet_sessions")
+	cutoff := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	var removed atomic.Int64
+	prune := func(ctx context.Context, client redis.UniversalClient) error {
+		iter := client.Scan(ctx, 0, pattern, scanCount).Iterator()
+		for iter.Next(ctx) {
+			n, err := client.ZRemRangeByScore(ctx, iter.Val(), "-inf", cutoff).Result()
+			if err != nil {
+				return err
+			}
+			removed.Add(n)
+		}
+		return iter.Err()
+	}
+
+	// SCAN only covers the node it is sent to
+	var err error
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		err = cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return prune(ctx, node)
+		})
+	} else {
+		err = prune(ctx, r.client)
+	}
+	return int(removed.Load()), err
+}
+
+// Close closes the Redis connection
+func (r *RedisStore) Close() error {
+	return r.client.Close()
+}
+
+// get reads a session, whether or not it has expired
+func (r *RedisStore) get(ctx context.Context, client redis.Cmdable, sessionID string) (*auth.Session, error) {
+	data, err := client.Get(ctx, r.key(sessionID)).Bytes()
+	if err == redis.Nil {
+		return nil, auth.ErrSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session auth.Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// indexEntry is the wallet index member of a session, scored by its expiry
+func indexEntry(session *auth.Session) redis.Z {
+	return redis.Z{
+		Score:  float64(session.ExpiresAt.UnixMilli()),
+		Member: session.SessionID,
+	}
+}
+
+func (r *RedisStore) key(sessionID string) string {
+	return r.keys.Key("session", sessionID)
+}
+
+func (r *RedisStore) walletKey(walletAddress string) string {
+	return r.keys.Key("wallet_sessions", walletAddress)
+}
@@ -0,0 +1,244 @@
+package token
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"turboauth/internal/domain/auth"
+)
+
+// clockSkew is how far the clocks of the issuing and validating instances
+// may disagree
+const clockSkew = 30 * time.Second
+
+// Config configures token issuance
+type Config struct {
+	// Issuer is the iss claim of issued tokens, validated tokens must carry it
+	Issuer string
+
+	// Audience is the aud claim of issued tokens; empty leaves it out
+	Audience string
+
+	// KeyFiles lists PEM key files; the first one signs new tokens and the
+	// others only verify, so a key can be published before it is promoted and
+	// kept after it is retired until its tokens have expired. Without key
+	// files a random key is generated, which other instances cannot verify
+	// and which is lost on restart.
+	KeyFiles []string
+}
+
+// Issuer implements TokenPort with JSON Web Tokens signed with Ed25519
+// (EdDSA) or ECDSA P-256 (ES256)
+type Issuer struct {
+	issuer      string
+	audience    string
+	signing     *key
+	keys        map[string]*key
+	jwks        []auth.JSONWebKey
+	revocations auth.RevocationPort
+}
+
+// header is the JOSE header of a token
+type header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ"`
+	KeyID     string `json:"kid"`
+}
+
+// claims is the payload of a token
+type claims struct {
+	Issuer     string `json:"iss"`
+	Audience   string `json:"aud,omitempty"`
+	Subject    string `json:"sub"` // wallet address
+	TokenID    string `json:"jti"`
+	IssuedAt   int64  `json:"iat"`
+	NotBefore  int64  `json:"nbf"`
+	ExpiresAt  int64  `json:"exp"`
+	SessionID  string `json:"sid"`
+	Status     string `json:"status"`
+	TrustScore int    `json:"trust_score"`
+}
+
+// NewIssuer creates a token issuer from the configured keys
+// Validated tokens are checked against revocations unless it is nil.
+func NewIssuer(cfg Config, revocations auth.RevocationPort) (*Issuer, error) {
+	var keys []*key
+	for _, path := range cfg.KeyFiles {
+		k, err := loadKeyFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load token key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+
+	if len(keys) == 0 {
+		k, err := generateKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate token key: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	if keys[0].signer == nil {
+		return nil, errors.New("the first token key must be a private key")
+	}
+
+	issuer := &Issuer{
+		issuer:      cfg.Issuer,
+		audience:    cfg.Audience,
+		signing:     keys[0],
+		keys:        make(map[string]*key, len(keys)),
+		revocations: revocations,
+	}
+	for _, k := range keys {
+		if _, ok := issuer.keys[k.id]; ok {
+			return nil, fmt.Errorf("token key %s is listed twice", k.id)
+		}
+		issuer.keys[k.id] = k
+		issuer.jwks = append(issuer.jwks, k.jwk)
+	}
+
+	return issuer, nil
+}
+
+// GenerateToken signs a token carrying claims with the current signing key
+func (i *Issuer) GenerateToken(tokenClaims *auth.TokenClaims) (string, error) {
+	tokenID, err := generateTokenID()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	tokenClaims.TokenID = tokenID
+	tokenClaims.IssuedAt = now
+
+	headerJSON, err := json.Marshal(header{
+		Algorithm: i.signing.algorithm,
+		Type:      "JWT",
+		KeyID:     i.signing.id,
+	})
+	if err != nil {
+		return "", err
+	}
+	claimsJSON, err := json.Marshal(claims{
+		Issuer:     i.issuer,
+		Audience:   i.audience,
+		Subject:    tokenClaims.WalletAddress,
+		TokenID:    tokenID,
+		IssuedAt:   now.Unix(),
+		NotBefore:  now.Unix(),
+		ExpiresAt:  tokenClaims.ExpiresAt.Unix(),
+		SessionID:  tokenClaims.SessionID,
+		Status:     string(tokenClaims.Status),
+		TrustScore: tokenClaims.TrustScore,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := b64.EncodeToString(headerJSON) + "." + b64.EncodeToString(claimsJSON)
+	sig, err := i.signing.sign([]byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + b64.EncodeToString(sig), nil
+}
+
+// ValidateToken verifies a token against the key its header names, checks
+// its issuer, audience and lifetime, and that it has not been revoked
+// Failing to read the revocation list fails validation.
+func (i *Issuer) ValidateToken(ctx context.Context, token string) (*auth.TokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", auth.ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, err
+	}
+
+	// The algorithm is fixed by the key, never chosen by the token
+	k, ok := i.keys[h.KeyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key %q", auth.ErrInvalidToken, h.KeyID)
+	}
+	if h.Algorithm != k.algorithm {
+		return nil, fmt.Errorf("%w: unexpected algorithm %q", auth.ErrInvalidToken, h.Algorithm)
+	}
+
+	sig, err := b64.DecodeString(parts[2])
+	if err != nil || !k.verify([]byte(parts[0]+"."+parts[1]), sig) {
+		return nil, fmt.Errorf("%w: bad signature", auth.ErrInvalidToken)
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch {
+	case c.Issuer != i.issuer:
+		return nil, fmt.Errorf("%w: unexpected issuer %q", auth.ErrInvalidToken, c.Issuer)
+	case c.Audience != i.audience:
+		return nil, fmt.Errorf("%w: unexpected audience %q", auth.ErrInvalidToken, c.Audience)
+	case now.Add(-clockSkew).After(time.Unix(c.ExpiresAt, 0)):
+		return nil, fmt.Errorf("%w: expired", auth.ErrInvalidToken)
+	case now.Add(clockSkew).Before(time.Unix(c.NotBefore, 0)):
+		return nil, fmt.Errorf("%w: not valid yet", auth.ErrInvalidToken)
+	}
+
+	tokenClaims := &auth.TokenClaims{
+		TokenID:       c.TokenID,
+		WalletAddress: c.Subject,
+		SessionID:     c.SessionID,
+		Status:        auth.AuthStatus(c.Status),
+		TrustScore:    c.TrustScore,
+		IssuedAt:      time.Unix(c.IssuedAt, 0),
+		ExpiresAt:     time.Unix(c.ExpiresAt, 0),
+	}
+
+	if i.revocations != nil {
+		revoked, err := i.revocations.IsRevoked(ctx, tokenClaims)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check token revocation: %w", err)
+		}
+		if revoked {
+			return nil, fmt.Errorf("%w: revoked", auth.ErrInvalidToken)
+		}
+	}
+
+	return tokenClaims, nil
+}
+
+// PublicKeys returns the signing key followed by the verification-only keys
+func (i *Issuer) PublicKeys() []auth.JSONWebKey {
+	return append([]auth.JSONWebKey(nil), i.jwks...)
+}
+
+// decodeSegment decodes a base64url JSON token segment into v
+func decodeSegment(segment string, v any) error {
+	data, err := b64.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: malformed token", auth.ErrInvalidToken)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: malformed token", auth.ErrInvalidToken)
+	}
+	return nil
+}
+
+func generateTokenID() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,406 @@
+package token
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"turboauth/internal/adapters/secondary/revocation"
+	"turboauth/internal/domain/auth"
+)
+
+// writeKeyFile writes a private key as a PKCS#8 PEM file and returns its path
+func writeKeyFile(t *testing.T, private any) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatalf("MarshalPKCS8PrivateKey: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("writing key file: %v", err)
+	}
+	return path
+}
+
+// writePublicKeyFile writes the public half of a key as a PKIX PEM file
+func writePublicKeyFile(t *testing.T, public any) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatalf("MarshalPKIXPublicKey: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "public.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("writing key file: %v", err)
+	}
+	return path
+}
+
+// testKeyFiles returns a key file for each supported algorithm
+func testKeyFiles(t *testing.T) map[string]string {
+	t.Helper()
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("ecdsa.GenerateKey: %v", err)
+	}
+
+	return map[string]string{
+		AlgEdDSA: writeKeyFile(t, edKey),
+		AlgES256: writeKeyFile(t, ecKey),
+	}
+}
+
+// newTestIssuer creates an issuer for "turboauth-test" tokens from keyFiles
+func newTestIssuer(t *testing.T, revocations auth.RevocationPort, keyFiles ...string) *Issuer {
+	t.Helper()
+
+	issuer, err := NewIssuer(Config{Issuer: "turboauth-test", Audience: "api", KeyFiles: keyFiles}, revocations)
+	if err != nil {
+		t.Fatalf("NewIssuer: %v", err)
+	}
+	return issuer
+}
+
+// testTokenClaims returns claims for a token expiring in an hour
+func testTokenClaims() *auth.TokenClaims {
+	return &auth.TokenClaims{
+		WalletAddress: "wallet",
+		SessionID:     "session",
+		Status:        auth.StatusActive,
+		TrustScore:    80,
+		ExpiresAt:     time.Now().Add(time.Hour),
+	}
+}
+
+// issue generates a token for testTokenClaims
+func issue(t *testing.T, issuer *Issuer) string {
+	t.Helper()
+
+	token, err := issuer.GenerateToken(testTokenClaims())
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	return token
+}
+
+// signSegments signs a token made of h and c with the issuer's signing key
+func signSegments(t *testing.T, issuer *Issuer, h header, c claims) string {
+	t.Helper()
+
+	headerJSON, err := json.Marshal(h)
+	if err != nil {
+		t.Fatal(err)
+	}
+	claimsJSON, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signingInput := b64.EncodeToString(headerJSON) + "." + b64.EncodeToString(claimsJSON)
+	sig, err := issuer.signing.sign([]byte(signingInput))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return signingInput + "." + b64.EncodeToString(sig)
+}
+
+// validHeader and validClaims are what issuer would put in a token issued now
+func validHeader(issuer *Issuer) header {
+	return header{Algorithm: issuer.signing.algorithm, Type: "JWT", KeyID: issuer.signing.id}
+}
+
+func validClaims(issuer *Issuer) claims {
+	now := time.Now().Unix()
+	return claims{
+		Issuer:    issuer.issuer,
+		Audience:  issuer.audience,
+		Subject:   "wallet",
+		TokenID:   "token",
+		IssuedAt:  now,
+		NotBefore: now,
+		ExpiresAt: now + 3600,
+		SessionID: "session",
+		Status:    string(auth.StatusActive),
+	}
+}
+
+func TestTokenRoundTrip(t *testing.T) {
+	for alg, keyFile := range testKeyFiles(t) {
+		t.Run(alg, func(t *testing.T) {
+			issuer := newTestIssuer(t, nil, keyFile)
+			want := testTokenClaims()
+
+			token, err := issuer.GenerateToken(want)
+			if err != nil {
+				t.Fatalf("GenerateToken: %v", err)
+			}
+
+			var h header
+			if err := decodeSegment(strings.Split(token, ".")[0], &h); err != nil {
+				t.Fatalf("decoding header: %v", err)
+			}
+			if h.Algorithm != alg || h.KeyID != issuer.PublicKeys()[0].KeyID {
+				t.Errorf("header = %+v, want alg %s and kid %s", h, alg, issuer.PublicKeys()[0].KeyID)
+			}
+
+			got, err := issuer.ValidateToken(context.Background(), token)
+			if err != nil {
+				t.Fatalf("ValidateToken: %v", err)
+			}
+			if got.TokenID == "" || got.TokenID != want.TokenID {
+				t.Errorf("TokenID = %q, want the generated %q", got.TokenID, want.TokenID)
+			}
+			if got.WalletAddress != want.WalletAddress || got.SessionID != want.SessionID ||
+				got.Status != want.Status || got.TrustScore != want.TrustScore {
+				t.Errorf("claims = %+v, want %+v", got, want)
+			}
+			if got.IssuedAt.Unix() != want.IssuedAt.Unix() || got.ExpiresAt.Unix() != want.ExpiresAt.Unix() {
+				t.Errorf("lifetime = %v..%v, want %v..%v", got.IssuedAt, got.ExpiresAt, want.IssuedAt, want.ExpiresAt)
+			}
+		})
+	}
+}
+
+func TestValidateTokenRejects(t *testing.T) {
+	keyFiles := testKeyFiles(t)
+	issuer := newTestIssuer(t, nil, keyFiles[AlgEdDSA])
+	other := newTestIssuer(t, nil, keyFiles[AlgES256])
+	now := time.Now().Unix()
+	skew := int64(clockSkew / time.Second)
+
+	tests := []struct {
+		name  string
+		token func() string
+	}{
+		{
+			name:  "malformed",
+			token: func() string { return "not.a-token" },
+		},
+		{
+			name: "tampered payload",
+			token: func() string {
+				parts := strings.Split(issue(t, issuer), ".")
+				forged := validClaims(issuer)
+				forged.Subject = "other-wallet"
+				claimsJSON, _ := json.Marshal(forged)
+				return parts[0] + "." + b64.EncodeToString(claimsJSON) + "." + parts[2]
+			},
+		},
+		{
+			name: "tampered signature",
+			token: func() string {
+				parts := strings.Split(issue(t, issuer), ".")
+				sig, _ := b64.DecodeString(parts[2])
+				sig[0] ^= 0x01
+				return parts[0] + "." + parts[1] + "." + b64.EncodeToString(sig)
+			},
+		},
+		{
+			name: "algorithm other than the key's",
+			token: func() string {
+				h := validHeader(issuer)
+				h.Algorithm = AlgES256
+				return signSegments(t, issuer, h, validClaims(issuer))
+			},
+		},
+		{
+			name: "alg none",
+			token: func() string {
+				h := validHeader(issuer)
+				h.Algorithm = "none"
+				headerJSON, _ := json.Marshal(h)
+				claimsJSON, _ := json.Marshal(validClaims(issuer))
+				return b64.EncodeToString(headerJSON) + "." + b64.EncodeToString(claimsJSON) + "."
+			},
+		},
+		{
+			name:  "unknown kid",
+			token: func() string { return issue(t, other) },
+		},
+		{
+			name: "wrong issuer",
+			token: func() string {
+				c := validClaims(issuer)
+				c.Issuer = "someone-else"
+				return signSegments(t, issuer, validHeader(issuer), c)
+			},
+		},
+		{
+			name: "wrong audience",
+			token: func() string {
+				c := validClaims(issuer)
+				c.Audience = "other-api"
+				return signSegments(t, issuer, validHeader(issuer), c)
+			},
+		},
+		{
+			name: "missing audience",
+			token: func() string {
+				c := validClaims(issuer)
+				c.Audience = ""
+				return signSegments(t, issuer, validHeader(issuer), c)
+			},
+		},
+		{
+			name: "expired beyond the clock skew",
+			token: func() string {
+				c := validClaims(issuer)
+				c.ExpiresAt = now - skew - 2
+				return signSegments(t, issuer, validHeader(issuer), c)
+			},
+		},
+		{
+			name: "not valid until beyond the clock skew",
+			token: func() string {
+				c := validClaims(issuer)
+				c.NotBefore = now + skew + 2
+				return signSegments(t, issuer, validHeader(issuer), c)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := issuer.ValidateToken(context.Background(), tt.token())
+			if !errors.Is(err, auth.ErrInvalidToken) {
+				t.Errorf("ValidateToken error = %v, want %v", err, auth.ErrInvalidToken)
+			}
+		})
+	}
+}
+
+func TestValidateTokenToleratesClockSkew(t *testing.T) {
+	issuer := newTestIssuer(t, nil, testKeyFiles(t)[AlgEdDSA])
+	now := time.Now().Unix()
+	skew := int64(clockSkew / time.Second)
+
+	tests := []struct {
+		name      string
+		notBefore int64
+		expiresAt int64
+	}{
+		{name: "expired within the skew", notBefore: now - 3600, expiresAt: now - skew + 2},
+		{name: "not valid until within the skew", notBefore: now + skew - 2, expiresAt: now + 3600},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := validClaims(issuer)
+			c.NotBefore, c.ExpiresAt = tt.notBefore, tt.expiresAt
+			if _, err := issuer.ValidateToken(context.Background(), signSegments(t, issuer, validHeader(issuer), c)); err != nil {
+				t.Errorf("ValidateToken: %v", err)
+			}
+		})
+	}
+}
+
+// failingRevocations is a revocation list that cannot be read
+type failingRevocations struct {
+	auth.RevocationPort
+}
+
+func (failingRevocations) IsRevoked(ctx context.Context, claims *auth.TokenClaims) (bool, error) {
+	return false, errors.New("revocation list unavailable")
+}
+
+func TestValidateTokenChecksRevocations(t *testing.T) {
+	keyFile := testKeyFiles(t)[AlgEdDSA]
+	ctx := context.Background()
+
+	revocations := revocation.NewMemoryStore()
+	t.Cleanup(revocations.Stop)
+	issuer := newTestIssuer(t, revocations, keyFile)
+
+	revoked := issue(t, issuer)
+	kept := issue(t, issuer)
+	claims, err := issuer.ValidateToken(ctx, revoked)
+	if err != nil {
+		t.Fatalf("ValidateToken before revocation: %v", err)
+	}
+	if err := revocations.RevokeToken(ctx, claims.TokenID, claims.ExpiresAt); err != nil {
+		t.Fatalf("RevokeToken: %v", err)
+	}
+
+	if _, err := issuer.ValidateToken(ctx, revoked); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("ValidateToken of a revoked token: error = %v, want %v", err, auth.ErrInvalidToken)
+	}
+	if _, err := issuer.ValidateToken(ctx, kept); err != nil {
+		t.Errorf("ValidateToken of another token: %v", err)
+	}
+
+	// A revocation list that cannot be read fails validation, but the token
+	// is not reported as invalid
+	unavailable := newTestIssuer(t, failingRevocations{}, keyFile)
+	if _, err := unavailable.ValidateToken(ctx, kept); err == nil || errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("ValidateToken without a revocation list: error = %v, want a lookup error", err)
+	}
+}
+
+func TestRetiredKeysStillVerify(t *testing.T) {
+	keyFiles := testKeyFiles(t)
+	retired := newTestIssuer(t, nil, keyFiles[AlgES256])
+	token := issue(t, retired)
+
+	// The retired key is listed after the new signing key, as a public key
+	publicKeyFile := writePublicKeyFile(t, retired.signing.public)
+	issuer := newTestIssuer(t, nil, keyFiles[AlgEdDSA], publicKeyFile)
+
+	if _, err := issuer.ValidateToken(context.Background(), token); err != nil {
+		t.Errorf("ValidateToken of a token signed with the retired key: %v", err)
+	}
+
+	keys := issuer.PublicKeys()
+	if len(keys) != 2 || keys[0].Algorithm != AlgEdDSA || keys[1].KeyID != retired.signing.id {
+		t.Errorf("PublicKeys = %+v, want the signing key then the retired key", keys)
+	}
+
+	if _, err := NewIssuer(Config{KeyFiles: []string{publicKeyFile}}, nil); err == nil {
+		t.Error("NewIssuer with a public signing key: want an error")
+	}
+	if _, err := NewIssuer(Config{KeyFiles: []string{keyFiles[AlgEdDSA], keyFiles[AlgEdDSA]}}, nil); err == nil {
+		t.Error("NewIssuer with a key listed twice: want an error")
+	}
+}
+
+func TestKeyIDIsThumbprint(t *testing.T) {
+	// RFC 8037, appendix A.3
+	jwk := auth.JSONWebKey{
+		KeyType: "OKP",
+		Curve:   "Ed25519",
+		X:       "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo",
+	}
+	if got, want := thumbprint(jwk), "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"; got != want {
+		t.Errorf("thumbprint = %s, want %s", got, want)
+	}
+
+	// The published kid is the thumbprint of the published key
+	public, err := b64.DecodeString(jwk.X)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := newKey(ed25519.PublicKey(public))
+	if err != nil {
+		t.Fatalf("newKey: %v", err)
+	}
+	if k.jwk.KeyID != "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k" || k.jwk.X != jwk.X {
+		t.Errorf("JWK = %+v, want kid kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k", k.jwk)
+	}
+}
@@ -0,0 +1,189 @@
+package token
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"turboauth/internal/domain/auth"
+)
+
+// Signing algorithms (RFC 8037 and RFC 7518)
+const (
+	AlgEdDSA = "EdDSA"
+	AlgES256 = "ES256"
+)
+
+// es256Size is the size of each of r and s in an ES256 signature
+const es256Size = 32
+
+// b64 is the unpadded base64url encoding used throughout JOSE
+var b64 = base64.RawURLEncoding
+
+// key is a token signing key, or a verification key for a retired one
+type key struct {
+	id        string
+	algorithm string
+	signer    crypto.Signer // nil if the key can only verify
+	public    crypto.PublicKey
+	jwk       auth.JSONWebKey
+}
+
+// loadKeyFile reads a PEM encoded Ed25519 or P-256 key: a PKCS#8 or SEC 1
+// private key, or a PKIX public key for a key that no longer signs
+func loadKeyFile(path string) (*key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: no PEM block found", path)
+	}
+
+	var parsed any
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: unsupported PEM block %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	k, err := newKey(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return k, nil
+}
+
+// generateKey creates a random Ed25519 signing key
+func generateKey() (*key, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return newKey(private)
+}
+
+// newKey wraps a parsed private or public key
+// The key ID is the key's JWK thumbprint (RFC 7638), so it is stable across
+// instances and restarts without being configured.
+func newKey(parsed any) (*key, error) {
+	k := &key{}
+
+	switch typed := parsed.(type) {
+	case ed25519.PrivateKey:
+		k.signer = typed
+		k.public = typed.Public()
+	case *ecdsa.PrivateKey:
+		k.signer = typed
+		k.public = typed.Public()
+	default:
+		k.public = parsed
+	}
+
+	switch public := k.public.(type) {
+	case ed25519.PublicKey:
+		k.algorithm = AlgEdDSA
+		k.jwk = auth.JSONWebKey{
+			KeyType: "OKP",
+			Curve:   "Ed25519",
+			X:       b64.EncodeToString(public),
+		}
+	case *ecdsa.PublicKey:
+		if public.Curve != elliptic.P256() {
+			return nil, errors.New("only P-256 ECDSA keys are supported")
+		}
+		k.algorithm = AlgES256
+		k.jwk = auth.JSONWebKey{
+			KeyType: "EC",
+			Curve:   "P-256",
+			X:       b64.EncodeToString(public.X.FillBytes(make([]byte, es256Size))),
+			Y:       b64.EncodeToString(public.Y.FillBytes(make([]byte, es256Size))),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+
+	k.id = thumbprint(k.jwk)
+	k.jwk.KeyID = k.id
+	k.jwk.Algorithm = k.algorithm
+	k.jwk.Use = "sig"
+	return k, nil
+}
+
+// sign signs data with the private key
+func (k *key) sign(data []byte) ([]byte, error) {
+	switch signer := k.signer.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(signer, data), nil
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256(data)
+		r, s, err := ecdsa.Sign(rand.Reader, signer, digest[:])
+		if err != nil {
+			return nil, err
+		}
+		// JWS uses the fixed-size r || s form rather than ASN.1
+		sig := make([]byte, 2*es256Size)
+		r.FillBytes(sig[:es256Size])
+		s.FillBytes(sig[es256Size:])
+		return sig, nil
+	default:
+		return nil, errors.New("key cannot sign")
+	}
+}
+
+// verify reports whether sig is a valid signature of data
+func (k *key) verify(data, sig []byte) bool {
+	switch public := k.public.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(public, data, sig)
+	case *ecdsa.PublicKey:
+		if len(sig) != 2*es256Size {
+			return false
+		}
+		digest := sha256.Sum256(data)
+		r := new(big.Int).SetBytes(sig[:es256Size])
+		s := new(big.Int).SetBytes(sig[es256Size:])
+		return ecdsa.Verify(public, digest[:], r, s)
+	default:
+		return false
+	}
+}
+
+// thumbprint computes the RFC 7638 thumbprint of a public key: the hash of
+// its required members, in lexicographic order and without whitespace
+func thumbprint(jwk auth.JSONWebKey) string {
+	members := map[string]string{
+		"kty": jwk.KeyType,
+		"crv": jwk.Curve,
+		"x":   jwk.X,
+	}
+	if jwk.Y != "" {
+		members["y"] = jwk.Y
+	}
+
+	// encoding/json writes map keys sorted
+	data, _ := json.Marshal(members)
+	sum := sha256.Sum256(data)
+	return b64.EncodeToString(sum[:])
+}
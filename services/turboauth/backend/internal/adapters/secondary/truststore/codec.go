@@ -0,0 +1,147 @@
+package truststore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"turboauth/internal/domain/auth"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// codecVersion is the first byte of every binary-encoded entry
+// Legacy entries are JSON objects and start with '{' instead.
+const codecVersion byte = 1
+
+// Field numbers of the binary encoding (protobuf wire format)
+const (
+	fieldWalletAddress   protowire.Number = 1
+	fieldStatus          protowire.Number = 2
+	fieldTrustScore      protowire.Number = 3
+	fieldContractAddress protowire.Number = 4
+	fieldUpdatedAt       protowire.Number = 5 // Unix nanoseconds
+	fieldCreatedAt       protowire.Number = 6 // Unix nanoseconds
+	fieldFetchedAt       protowire.Number = 7 // Unix nanoseconds
+	fieldStale           protowire.Number = 8
+)
+
+var errMalformedEntry = errors.New("malformed cache entry")
+
+// encodeWalletAuth encodes an entry as a version byte followed by protobuf
+// wire-format fields; empty fields are omitted
+func encodeWalletAuth(data *auth.WalletAuth) []byte {
+	buf := make([]byte, 0, 128)
+	buf = append(buf, codecVersion)
+
+	buf = appendString(buf, fieldWalletAddress, data.WalletAddress)
+	buf = appendString(buf, fieldStatus, string(data.Status))
+	if data.TrustScore != 0 {
+		buf = protowire.AppendTag(buf, fieldTrustScore, protowire.VarintType)
+		buf = protowire.AppendVarint(buf, protowire.EncodeZigZag(int64(data.TrustScore)))
+	}
+	buf = appendString(buf, fieldContractAddress, data.ContractAddress)
+	buf = appendTime(buf, fieldUpdatedAt, data.UpdatedAt)
+	buf = appendTime(buf, fieldCreatedAt, data.CreatedAt)
+	buf = appendTime(buf, fieldFetchedAt, data.FetchedAt)
+	if data.Stale {
+		buf = protowire.AppendTag(buf, fieldStale, protowire.VarintType)
+		buf = protowire.AppendVarint(buf, 1)
+	}
+
+	return buf
+}
+
+// decodeWalletAuth decodes a binary entry, or a legacy JSON entry written
+// before the binary encoding was introduced
+func decodeWalletAuth(raw []byte) (*auth.WalletAuth, error) {
+	if len(raw) == 0 {
+		return nil, errMalformedEntry
+	}
+
+	var data auth.WalletAuth
+	switch raw[0] {
+	case '{':
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return nil, err
+		}
+		return &data, nil
+	case codecVersion:
+	default:
+		return nil, fmt.Errorf("%w: unknown version %d", errMalformedEntry, raw[0])
+	}
+
+	b := raw[1:]
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return nil, errMalformedEntry
+		}
+		b = b[n:]
+
+		switch {
+		case typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(b)
+			if n < 0 {
+				return nil, errMalformedEntry
+			}
+			b = b[n:]
+
+			switch num {
+			case fieldWalletAddress:
+				data.WalletAddress = v
+			case fieldStatus:
+				data.Status = auth.AuthStatus(v)
+			case fieldContractAddress:
+				data.ContractAddress = v
+			}
+		case typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return nil, errMalformedEntry
+			}
+			b = b[n:]
+
+			switch num {
+			case fieldTrustScore:
+				data.TrustScore = int(protowire.DecodeZigZag(v))
+			case fieldUpdatedAt:
+				data.UpdatedAt = time.Unix(0, protowire.DecodeZigZag(v))
+			case fieldCreatedAt:
+				data.CreatedAt = time.Unix(0, protowire.DecodeZigZag(v))
+			case fieldFetchedAt:
+				data.FetchedAt = time.Unix(0, protowire.DecodeZigZag(v))
+			case fieldStale:
+				data.Stale = v != 0
+			}
+		default:
+			// Skip fields added by newer versions
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return nil, errMalformedEntry
+			}
+			b = b[n:]
+		}
+	}
+
+	return &data, nil
+}
+
+func appendString(buf []byte, num protowire.Number, v string) []byte {
+	if v == "" {
+		return buf
+	}
+	buf = protowire.AppendTag(buf, num, protowire.BytesType)
+	return protowire.AppendString(buf, v)
+}
+
+// appendTime encodes t as Unix nanoseconds; the zero time is omitted, as it
+// does not fit in an int64
+func appendTime(buf []byte, num protowire.Number, t time.Time) []byte {
+	if t.IsZero() {
+		return buf
+	}
+	buf = protowire.AppendTag(buf, num, protowire.VarintType)
+	return protowire.AppendVarint(buf, protowire.EncodeZigZag(t.UnixNano()))
+}
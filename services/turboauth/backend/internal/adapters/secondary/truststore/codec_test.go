@@ -0,0 +1,147 @@
+package truststore
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"turboauth/internal/domain/auth"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// testEntry returns an entry with every field set
+func testEntry() *auth.WalletAuth {
+	return &auth.WalletAuth{
+		WalletAddress:   "BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK",
+		Status:          auth.StatusActive,
+		TrustScore:      87,
+		ContractAddress: "NAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAMAML",
+		UpdatedAt:       time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+		CreatedAt:       time.Date(2023, 1, 15, 8, 30, 0, 0, time.UTC),
+		FetchedAt:       time.Date(2024, 5, 1, 12, 0, 5, 123456789, time.UTC),
+		Stale:           true,
+	}
+}
+
+// negativeTestEntry mirrors the entry cached for wallets the contract does not know
+func negativeTestEntry() *auth.WalletAuth {
+	return &auth.WalletAuth{
+		WalletAddress: "BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK",
+		Status:        auth.StatusUnknown,
+		FetchedAt:     time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+// assertEntry fails unless got holds the same status as want
+func assertEntry(t *testing.T, got, want *auth.WalletAuth) {
+	t.Helper()
+
+	if got.WalletAddress != want.WalletAddress ||
+		got.Status != want.Status ||
+		got.TrustScore != want.TrustScore ||
+		got.ContractAddress != want.ContractAddress ||
+		!got.UpdatedAt.Equal(want.UpdatedAt) ||
+		!got.CreatedAt.Equal(want.CreatedAt) ||
+		!got.FetchedAt.Equal(want.FetchedAt) ||
+		got.Stale != want.Stale {
+		t.Errorf("decoded entry = %+v, want %+v", got, want)
+	}
+}
+
+func TestCodecRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry *auth.WalletAuth
+	}{
+		{name: "all fields", entry: testEntry()},
+		{name: "empty", entry: &auth.WalletAuth{}},
+		{name: "blocked with zero score", entry: &auth.WalletAuth{
+			WalletAddress: "BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK",
+			Status:        auth.StatusBlocked,
+			UpdatedAt:     time.Unix(1714564800, 0),
+		}},
+		{name: "unknown wallet", entry: negativeTestEntry()},
+		{name: "time before 1970", entry: &auth.WalletAuth{UpdatedAt: time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded := encodeWalletAuth(tt.entry)
+			if encoded[0] != codecVersion {
+				t.Fatalf("version byte = %d, want %d", encoded[0], codecVersion)
+			}
+
+			decoded, err := decodeWalletAuth(encoded)
+			if err != nil {
+				t.Fatalf("decodeWalletAuth: %v", err)
+			}
+			assertEntry(t, decoded, tt.entry)
+		})
+	}
+}
+
+func TestDecodeLegacyJSON(t *testing.T) {
+	want := testEntry()
+	legacy, err := json.Marshal(want)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	if legacy[0] != '{' {
+		t.Fatalf("legacy entry starts with %q, want '{'", legacy[0])
+	}
+
+	got, err := decodeWalletAuth(legacy)
+	if err != nil {
+		t.Fatalf("decodeWalletAuth: %v", err)
+	}
+	assertEntry(t, got, want)
+
+	if _, err := decodeWalletAuth([]byte(`{"wallet_address":`)); err == nil {
+		t.Error("decodeWalletAuth accepted truncated JSON")
+	}
+}
+
+func TestDecodeSkipsUnknownFields(t *testing.T) {
+	want := testEntry()
+	encoded := encodeWalletAuth(want)
+
+	// Fields a newer version might add, of every wire type
+	encoded = protowire.AppendTag(encoded, 100, protowire.BytesType)
+	encoded = protowire.AppendString(encoded, "added later")
+	encoded = protowire.AppendTag(encoded, 101, protowire.Fixed64Type)
+	encoded = protowire.AppendFixed64(encoded, 42)
+	encoded = protowire.AppendTag(encoded, 102, protowire.Fixed32Type)
+	encoded = protowire.AppendFixed32(encoded, 7)
+
+	got, err := decodeWalletAuth(encoded)
+	if err != nil {
+		t.Fatalf("decodeWalletAuth: %v", err)
+	}
+	assertEntry(t, got, want)
+}
+
+func TestDecodeRejectsMalformedEntries(t *testing.T) {
+	encoded := encodeWalletAuth(testEntry())
+
+	tests := []struct {
+		name string
+		raw  []byte
+	}{
+		{name: "empty", raw: nil},
+		{name: "unknown version", raw: append([]byte{codecVersion + 1}, encoded[1:]...)},
+		{name: "version zero", raw: append([]byte{0}, encoded[1:]...)},
+		{name: "truncated", raw: encoded[:len(encoded)-3]},
+		{name: "truncated tag", raw: []byte{codecVersion, 0x80}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := decodeWalletAuth(tt.raw)
+			if !errors.Is(err, errMalformedEntry) {
+				t.Fatalf("decodeWalletAuth error = %v, want %v", err, errMalformedEntry)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package truststore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"turboauth/pkg/metrics"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+// subscribeRetryInterval is how long to wait after the subscription fails
+const subscribeRetryInterval = time.Second
+
+// subscribe drops L1 entries invalidated by other instances until the store
+// is closed. Invalidations published while the subscription is down are lost,
+// so L1 is flushed whenever the subscription is re-established.
+func (t *TieredStore) subscribe(l2 *RedisStore) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		<-t.closed
+		cancel()
+	}()
+
+	pubsub := l2.SubscribeInvalidations(ctx)
+	defer pubsub.Close()
+
+	subscribed := false
+	for {
+		msg, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			// go-redis reconnects and resubscribes on the next Receive
+			log.Warn().Err(err).Msg("Cache invalidation subscription interrupted")
+			time.Sleep(subscribeRetryInterval)
+			continue
+		}
+
+		switch msg := msg.(type) {
+		case *redis.Subscription:
+			if msg.Kind != "subscribe" {
+				continue
+			}
+			if subscribed {
+				t.flushL1("resubscribe")
+			}
+			subscribed = true
+		case *redis.Message:
+			t.handleInvalidation(msg.Payload)
+		}
+	}
+}
+
+// handleInvalidation drops an entry deleted by another instance from L1
+func (t *TieredStore) handleInvalidation(payload string) {
+	instanceID, walletAddress, ok := strings.Cut(payload, ":")
+	if !ok || instanceID == t.instanceID {
+		return
+	}
+
+	_ = t.l1.Delete(context.Background(), walletAddress)
+	metrics.CacheInvalidations.WithLabelValues("remote").Inc()
+}
+
+// flushL1 empties the memory cache after invalidations may have been missed
+func (t *TieredStore) flushL1(reason string) {
+	t.l1.Clear()
+	metrics.CacheInvalidations.WithLabelValues(reason).Inc()
+	log.Info().Str("reason", reason).Msg("Flushed memory cache")
+}
+
+// newInstanceID identifies this instance's own invalidations
+func newInstanceID() string {
+	bytes := make([]byte, 8)
+	_, _ = rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
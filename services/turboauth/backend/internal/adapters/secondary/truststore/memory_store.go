@@ -1,6 +1,7 @@
 package truststore
 
 import (
+	"container/list"
 	"context"
 	"fmt"
 	"sync"
@@ -10,20 +11,43 @@ import (
 	"turboauth/pkg/metrics"
 )
 
+// entryOverhead approximates the memory used by an entry besides its strings:
+// the WalletAuth struct, timestamps, list element and map bucket
+const entryOverhead = 256
+
 // MemoryStore implements in-memory caching (L1 cache)
+// It is bounded by entry count and/or approximate size in bytes and evicts
+// the least recently used entries once either limit is reached.
 type MemoryStore struct {
-	data  sync.Map
-	mutex sync.RWMutex
+	maxEntries int
+	maxBytes   int64
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List // front is most recently used
+	bytes   int64
+
+	stop     chan struct{}
+	stopOnce sync.Once
 }
 
 type cacheEntry struct {
-	Data      *auth.WalletAuth
-	ExpiresAt time.Time
+	WalletAddress string
+	Data          *auth.WalletAuth
+	ExpiresAt     time.Time
+	Size          int64
 }
 
 // NewMemoryStore creates a new in-memory store
-func NewMemoryStore() *MemoryStore {
-	store := &MemoryStore{}
+// maxEntries and maxBytes bound the cache; zero leaves that dimension unbounded.
+func NewMemoryStore(maxEntries int, maxBytes int64) *MemoryStore {
+	store := &MemoryStore{
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		entries:    make(map[string]*list.Element),
+		lru:        list.New(),
+		stop:       make(chan struct{}),
+	}
 
 	// Start cleanup goroutine
 	go store.cleanupExpired()
@@ -33,39 +57,84 @@ func NewMemoryStore() *MemoryStore {
 
 // Get retrieves cached data
 func (m *MemoryStore) Get(ctx context.Context, walletAddress string) (*auth.WalletAuth, error) {
-	value, ok := m.data.Load(walletAddress)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	elem, ok := m.entries[walletAddress]
 	if !ok {
 		metrics.CacheMisses.WithLabelValues("L1").Inc()
 		return nil, fmt.Errorf("not found")
 	}
 
-	entry := value.(*cacheEntry)
+	entry := elem.Value.(*cacheEntry)
 	if time.Now().After(entry.ExpiresAt) {
-		m.data.Delete(walletAddress)
+		m.remove(elem)
+		metrics.CacheEvictions.WithLabelValues("L1", "expired").Inc()
 		metrics.CacheMisses.WithLabelValues("L1").Inc()
 		return nil, fmt.Errorf("expired")
 	}
 
+	m.lru.MoveToFront(elem)
 	metrics.CacheHits.WithLabelValues("L1").Inc()
 	return entry.Data, nil
 }
 
-// Set stores data in cache
+// Set stores data in cache, evicting the least recently used entries if the
+// store is full
 func (m *MemoryStore) Set(ctx context.Context, walletAddress string, data *auth.WalletAuth, ttl time.Duration) error {
 	entry := &cacheEntry{
-		Data:      data,
-		ExpiresAt: time.Now().Add(ttl),
+		WalletAddress: walletAddress,
+		Data:          data,
+		ExpiresAt:     time.Now().Add(ttl),
+		Size:          entrySize(walletAddress, data),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[walletAddress]; ok {
+		m.remove(elem)
 	}
-	m.data.Store(walletAddress, entry)
+
+	// An entry larger than the whole cache would only evict everything else
+	if m.maxBytes > 0 && entry.Size > m.maxBytes {
+		return nil
+	}
+
+	m.entries[walletAddress] = m.lru.PushFront(entry)
+	m.bytes += entry.Size
+
+	for m.overCapacity() {
+		m.remove(m.lru.Back())
+		metrics.CacheEvictions.WithLabelValues("L1", "capacity").Inc()
+	}
+
+	metrics.CacheEntries.WithLabelValues("L1").Set(float64(len(m.entries)))
 	return nil
 }
 
 // Delete removes data from cache
 func (m *MemoryStore) Delete(ctx context.Context, walletAddress string) error {
-	m.data.Delete(walletAddress)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if elem, ok := m.entries[walletAddress]; ok {
+		m.remove(elem)
+	}
 	return nil
 }
 
+// Clear removes every entry
+func (m *MemoryStore) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[string]*list.Element)
+	m.lru.Init()
+	m.bytes = 0
+	metrics.CacheEntries.WithLabelValues("L1").Set(0)
+}
+
 // BatchGet retrieves multiple entries
 func (m *MemoryStore) BatchGet(ctx context.Context, walletAddresses []string) (map[string]*auth.WalletAuth, error) {
 	result := make(map[string]*auth.WalletAuth)
@@ -92,19 +161,60 @@ func (m *MemoryStore) HealthCheck(ctx context.Context) error {
 	return nil
 }
 
+// Stop ends the cleanup goroutine
+func (m *MemoryStore) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
+
+// overCapacity reports whether the store exceeds either of its limits
+func (m *MemoryStore) overCapacity() bool {
+	if m.maxEntries > 0 && len(m.entries) > m.maxEntries {
+		return true
+	}
+	return m.maxBytes > 0 && m.bytes > m.maxBytes
+}
+
+// remove drops an entry; the caller must hold the lock
+func (m *MemoryStore) remove(elem *list.Element) {
+	entry := m.lru.Remove(elem).(*cacheEntry)
+	delete(m.entries, entry.WalletAddress)
+	m.bytes -= entry.Size
+	metrics.CacheEntries.WithLabelValues("L1").Set(float64(len(m.entries)))
+}
+
 // cleanupExpired removes expired entries periodically
 func (m *MemoryStore) cleanupExpired() {
 	ticker := time.NewTicker(1 * time.Minute)
 	defer ticker.Stop()
 
-	for range ticker.C {
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		}
+
 		now := time.Now()
-		m.data.Range(func(key, value interface{}) bool {
-			entry := value.(*cacheEntry)
-			if now.After(entry.ExpiresAt) {
-				m.data.Delete(key)
+		m.mu.Lock()
+		for elem := m.lru.Back(); elem != nil; {
+			prev := elem.Prev()
+			if now.After(elem.Value.(*cacheEntry).ExpiresAt) {
+				m.remove(elem)
+				metrics.CacheEvictions.WithLabelValues("L1", "expired").Inc()
 			}
-			return true
-		})
+			elem = prev
+		}
+		m.mu.Unlock()
+	}
+}
+
+// entrySize approximates the memory used by an entry
+func entrySize(walletAddress string, data *auth.WalletAuth) int64 {
+	size := int64(entryOverhead + len(walletAddress))
+	if data != nil {
+		size += int64(len(data.WalletAddress) + len(data.Status) + len(data.ContractAddress))
 	}
+	return size
 }
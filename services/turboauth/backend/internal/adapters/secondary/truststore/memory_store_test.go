@@ -0,0 +1,87 @@
+package truststore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMemoryStoreEvictsLeastRecentlyRead(t *testing.T) {
+	size := entrySize("a", walletEntry("a"))
+
+	tests := []struct {
+		name       string
+		maxEntries int
+		maxBytes   int64
+	}{
+		{name: "entry limit", maxEntries: 3},
+		{name: "size limit", maxBytes: 3*size + size/2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemoryStore(tt.maxEntries, tt.maxBytes)
+			t.Cleanup(store.Stop)
+			ctx := context.Background()
+			evictions := testutil.ToFloat64(metrics.CacheEvictions.WithLabelValues("L1", "capacity"))
+
+			for _, wallet := range []string{"a", "b", "c"} {
+				if err := store.Set(ctx, wallet, walletEntry(wallet), time.Hour); err != nil {
+					t.Fatalf("Set: %v", err)
+				}
+			}
+
+			// Reading a makes b the least recently used entry
+			if _, err := store.Get(ctx, "a"); err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if err := store.Set(ctx, "d", walletEntry("d"), time.Hour); err != nil {
+				t.Fatalf("Set: %v", err)
+			}
+
+			got, _ := store.BatchGet(ctx, []string{"a", "b", "c", "d"})
+			if _, ok := got["b"]; ok || len(got) != 3 {
+				t.Errorf("entries after exceeding the capacity = %v, want a, c and d", keys(got))
+			}
+			if got := testutil.ToFloat64(metrics.CacheEvictions.WithLabelValues("L1", "capacity")) - evictions; got != 1 {
+				t.Errorf("capacity evictions = %v, want 1", got)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreSkipsEntriesLargerThanTheCache(t *testing.T) {
+	store := NewMemoryStore(0, entrySize("a", walletEntry("a")))
+	t.Cleanup(store.Stop)
+	ctx := context.Background()
+
+	if err := store.Set(ctx, "a", walletEntry("a"), time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	large := walletEntry("large")
+	large.ContractAddress += large.ContractAddress
+	if err := store.Set(ctx, "large", large, time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	if _, err := store.Get(ctx, "large"); err == nil {
+		t.Error("Get of an entry larger than the cache: want an error")
+	}
+	if _, err := store.Get(ctx, "a"); err != nil {
+		t.Errorf("Get after storing an oversized entry: %v", err)
+	}
+}
+
+// keys lists the wallets of a BatchGet result
+func keys(m map[string]*auth.WalletAuth) []string {
+	wallets := make([]string, 0, len(m))
+	for wallet := range m {
+		wallets = append(wallets, wallet)
+	}
+	return wallets
+}
@@ -2,116 +2,140 @@ package truststore
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"errors"
+	"strings"
+	"sync"
 	"time"
 
 	"turboauth/internal/domain/auth"
 	"turboauth/pkg/metrics"
+	"turboauth/pkg/redisclient"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// errNotFound is returned for cache misses
+var errNotFound = errors.New("not found")
+
+// invalidationChannel carries "<instance id>:<wallet>" for every deleted entry
+const invalidationChannel = "auth:invalidate"
+
+// scanCount is the number of keys SCAN is asked to inspect per call
+const scanCount = 1000
+
+// defaultPoolSize is used unless the options set a pool size
+const defaultPoolSize = 100 // High-performance connection pool
+
 // RedisStore implements Redis-based caching (L2 cache)
+// Entries are stored in a compact binary encoding (see codec.go); JSON
+// entries written by earlier versions are still read.
 type RedisStore struct {
-	client *redis.Client
+	client redis.UniversalClient
+	keys   redisclient.Keyspace
 }
 
 // NewRedisStore creates a new Redis store
-func NewRedisStore(url, password string, db int) (*RedisStore, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:     url,
-		Password: password,
-		DB:       db,
-		PoolSize: 100, // High-performance connection pool
-	})
-
-	// Test connection
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	if err := client.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+func NewRedisStore(opts redisclient.Options) (*RedisStore, error) {
+	if opts.PoolSize == 0 {
+		opts.PoolSize = defaultPoolSize
+	}
+
+	client, err := redisclient.New(opts)
+	if err != nil {
+		return nil, err
 	}
 
-	return &RedisStore{client: client}, nil
+	return &RedisStore{client: client, keys: opts.Keyspace()}, nil
 }
 
 // Get retrieves cached data from Redis
 func (r *RedisStore) Get(ctx context.Context, walletAddress string) (*auth.WalletAuth, error) {
-	key := fmt.Sprintf("auth:%s", walletAddress)
+	key := r.keys.Key("auth", walletAddress)
 
 	data, err := r.client.Get(ctx, key).Bytes()
 	if err == redis.Nil {
 		metrics.CacheMisses.WithLabelValues("L2").Inc()
-		return nil, fmt.Errorf("not found")
+		return nil, errNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
 
-	var walletAuth auth.WalletAuth
-	if err := json.Unmarshal(data, &walletAuth); err != nil {
+	walletAuth, err := decodeWalletAuth(data)
+	if err != nil {
 		return nil, err
 	}
 
 	metrics.CacheHits.WithLabelValues("L2").Inc()
-	return &walletAuth, nil
+	return walletAuth, nil
 }
 
 // Set stores data in Redis
 func (r *RedisStore) Set(ctx context.Context, walletAddress string, data *auth.WalletAuth, ttl time.Duration) error {
-	key := fmt.Sprintf("auth:%s", walletAddress)
-
-	jsonData, err := json.Marshal(data)
-	if err != nil {
-		return err
-	}
-
-	return r.client.Set(ctx, key, jsonData, ttl).Err()
+	key := r.keys.Key("auth", walletAddress)
+	return r.client.Set(ctx, key, encodeWalletAuth(data), ttl).Err()
 }
 
 // Delete removes data from Redis
 func (r *RedisStore) Delete(ctx context.Context, walletAddress string) error {
-	key := fmt.Sprintf("auth:%s", walletAddress)
+	key := r.keys.Key("auth", walletAddress)
 	return r.client.Del(ctx, key).Err()
 }
 
-// BatchGet retrieves multiple entries using pipeline
+// BatchGet retrieves multiple entries with one MGET per cluster slot, all in
+// a single pipeline
+// The keys of the cache are spread over all slots in cluster mode, and a
+// multi-key command must stay within one; other modes send a single MGET.
 func (r *RedisStore) BatchGet(ctx context.Context, walletAddresses []string) (map[string]*auth.WalletAuth, error) {
 	if len(walletAddresses) == 0 {
 		return make(map[string]*auth.WalletAuth), nil
 	}
 
-	// Use pipeline for performance
-	pipe := r.client.Pipeline()
-	cmds := make(map[string]*redis.StringCmd)
-
+	slots := make(map[int][]string)
 	for _, addr := range walletAddresses {
-		key := fmt.Sprintf("auth:%s", addr)
-		cmds[addr] = pipe.Get(ctx, key)
+		slot := r.keys.Slot(r.keys.Key("auth", addr))
+		slots[slot] = append(slots[slot], addr)
 	}
 
-	_, err := pipe.Exec(ctx)
-	if err != nil && err != redis.Nil {
-		// Partial failures are OK
+	pipe := r.client.Pipeline()
+	groups := make([][]string, 0, len(slots))
+	cmds := make([]*redis.SliceCmd, 0, len(slots))
+	for _, addrs := range slots {
+		keys := make([]string, len(addrs))
+		for i, addr := range addrs {
+			keys[i] = r.keys.Key("auth", addr)
+		}
+		groups = append(groups, addrs)
+		cmds = append(cmds, pipe.MGet(ctx, keys...))
 	}
 
+	// Misses show up as nil values; an error means Redis itself failed,
+	// which is reported alongside whatever entries were read
+	_, execErr := pipe.Exec(ctx)
+
 	result := make(map[string]*auth.WalletAuth)
-	for addr, cmd := range cmds {
-		data, err := cmd.Bytes()
-		if err == nil {
-			var walletAuth auth.WalletAuth
-			if json.Unmarshal(data, &walletAuth) == nil {
-				result[addr] = &walletAuth
+	for i, cmd := range cmds {
+		values := cmd.Val()
+		for j, addr := range groups[i] {
+			if j < len(values) {
+				if data, ok := values[j].(string); ok {
+					if walletAuth, err := decodeWalletAuth([]byte(data)); err == nil {
+						result[addr] = walletAuth
+						metrics.CacheHits.WithLabelValues("L2").Inc()
+						continue
+					}
+				}
 			}
+			metrics.CacheMisses.WithLabelValues("L2").Inc()
 		}
 	}
 
-	return result, nil
+	return result, execErr
 }
 
 // BatchSet stores multiple entries using pipeline
+// Every SET is a single-key command, so in cluster mode the pipeline is split
+// by slot and sent to each slot's node without further grouping.
 func (r *RedisStore) BatchSet(ctx context.Context, data map[string]*auth.WalletAuth, ttl time.Duration) error {
 	if len(data) == 0 {
 		return nil
@@ -120,18 +144,58 @@ func (r *RedisStore) BatchSet(ctx context.Context, data map[string]*auth.WalletA
 	pipe := r.client.Pipeline()
 
 	for addr, walletAuth := range data {
-		key := fmt.Sprintf("auth:%s", addr)
-		jsonData, err := json.Marshal(walletAuth)
-		if err != nil {
-			continue
-		}
-		pipe.Set(ctx, key, jsonData, ttl)
+		key := r.keys.Key("auth", addr)
+		pipe.Set(ctx, key, encodeWalletAuth(walletAuth), ttl)
 	}
 
 	_, err := pipe.Exec(ctx)
 	return err
 }
 
+// Wallets lists up to limit wallets with a cached entry, in no particular order
+func (r *RedisStore) Wallets(ctx context.Context, limit int) ([]string, error) {
+	prefix := r.keys.Key("auth", "")
+	pattern := r.keys.Pattern("auth")
+
+	var (
+		mu      sync.Mutex
+		wallets []string
+	)
+	scan := func(ctx context.Context, client redis.UniversalClient) error {
+		iter := client.Scan(ctx, 0, pattern, scanCount).Iterator()
+		for iter.Next(ctx) {
+			mu.Lock()
+			if limit > 0 && len(wallets) >= limit {
+				mu.Unlock()
+				return nil
+			}
+			wallets = append(wallets, strings.TrimPrefix(iter.Val(), prefix))
+			mu.Unlock()
+		}
+		return iter.Err()
+	}
+
+	// SCAN only covers the node it is sent to
+	if cluster, ok := r.client.(*redis.ClusterClient); ok {
+		err := cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			return scan(ctx, node)
+		})
+		return wallets, err
+	}
+	return wallets, scan(ctx, r.client)
+}
+
+// PublishInvalidation announces that an entry was deleted, so other instances
+// can drop it from their memory caches
+func (r *RedisStore) PublishInvalidation(ctx context.Context, instanceID, walletAddress string) error {
+	return r.client.Publish(ctx, r.keys.Channel(invalidationChannel), instanceID+":"+walletAddress).Err()
+}
+
+// SubscribeInvalidations subscribes to invalidations published by any instance
+func (r *RedisStore) SubscribeInvalidations(ctx context.Context) *redis.PubSub {
+	return r.client.Subscribe(ctx, r.keys.Channel(invalidationChannel))
+}
+
 // HealthCheck verifies Redis connectivity
 func (r *RedisStore) HealthCheck(ctx context.Context) error {
 	return r.client.Ping(ctx).Err()
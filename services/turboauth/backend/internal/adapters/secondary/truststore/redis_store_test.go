@@ -0,0 +1,109 @@
+package truststore
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/identity"
+	"turboauth/pkg/redisclient"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// batchSize is the number of wallets each benchmarked BatchGet reads
+const batchSize = 100
+
+// newTestRedisStore returns a store backed by an in-process Redis server
+func newTestRedisStore(tb testing.TB) *RedisStore {
+	tb.Helper()
+
+	server := miniredis.RunT(tb)
+	store, err := NewRedisStore(redisclient.Options{Addrs: []string{server.Addr()}})
+	if err != nil {
+		tb.Fatalf("NewRedisStore: %v", err)
+	}
+	tb.Cleanup(func() { store.Close() })
+	return store
+}
+
+// seedBatch stores batchSize entries encoded by encode and returns their wallets
+func seedBatch(tb testing.TB, store *RedisStore, encode func(*auth.WalletAuth) []byte) []string {
+	tb.Helper()
+
+	ctx := context.Background()
+	wallets := make([]string, batchSize)
+	for i := range wallets {
+		entry := testEntry()
+		entry.WalletAddress = identity.FromPublicKey([identity.PublicKeySize]byte{byte(i), byte(i >> 8), 1}, false)
+		wallets[i] = entry.WalletAddress
+
+		key := store.keys.Key("auth", entry.WalletAddress)
+		if err := store.client.Set(ctx, key, encode(entry), time.Hour).Err(); err != nil {
+			tb.Fatalf("seeding %s: %v", key, err)
+		}
+	}
+	return wallets
+}
+
+// encodeJSON encodes an entry the way versions before the binary codec did
+func encodeJSON(data *auth.WalletAuth) []byte {
+	raw, _ := json.Marshal(data)
+	return raw
+}
+
+func TestBatchGetReadsBothEncodings(t *testing.T) {
+	store := newTestRedisStore(t)
+	ctx := context.Background()
+
+	legacy := seedBatch(t, store, encodeJSON)[:batchSize/2]
+	current := testEntry()
+	current.WalletAddress = "BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK"
+	if err := store.Set(ctx, current.WalletAddress, current, time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	wallets := append([]string{current.WalletAddress}, legacy...)
+	got, err := store.BatchGet(ctx, wallets)
+	if err != nil {
+		t.Fatalf("BatchGet: %v", err)
+	}
+	if len(got) != len(wallets) {
+		t.Fatalf("BatchGet returned %d entries, want %d", len(got), len(wallets))
+	}
+	assertEntry(t, got[current.WalletAddress], current)
+	for _, wallet := range legacy {
+		want := testEntry()
+		want.WalletAddress = wallet
+		assertEntry(t, got[wallet], want)
+	}
+}
+
+func BenchmarkBatchGetJSON(b *testing.B) {
+	benchmarkBatchGet(b, encodeJSON)
+}
+
+func BenchmarkBatchGetBinary(b *testing.B) {
+	benchmarkBatchGet(b, encodeWalletAuth)
+}
+
+// benchmarkBatchGet measures BatchGet of batchSize entries encoded by encode
+func benchmarkBatchGet(b *testing.B, encode func(*auth.WalletAuth) []byte) {
+	store := newTestRedisStore(b)
+	wallets := seedBatch(b, store, encode)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		got, err := store.BatchGet(ctx, wallets)
+		if err != nil {
+			b.Fatalf("BatchGet: %v", err)
+		}
+		if len(got) != batchSize {
+			b.Fatalf("BatchGet returned %d entries, want %d", len(got), batchSize)
+		}
+	}
+}
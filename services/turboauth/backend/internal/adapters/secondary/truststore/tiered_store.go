@@ -0,0 +1,362 @@
+package truststore
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/metrics"
+	"turboauth/pkg/redisclient"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// reconnectInterval is how often an unavailable Redis is retried
+	reconnectInterval = 5 * time.Second
+
+	// maxPendingDeletes bounds the invalidations remembered while Redis is down
+	maxPendingDeletes = 10000
+)
+
+// errRedisUnavailable is returned by operations that need Redis while it is down
+var errRedisUnavailable = errors.New("redis unavailable")
+
+// TieredStore implements a two-level cache: memory (L1) in front of Redis (L2)
+// Reads go to L1 first and back-fill it on L2 hits; writes and deletes go to
+// both. Deletes are also published over Redis so every instance drops the
+// entry from its own L1. When Redis fails the store keeps serving from memory
+// alone, retries Redis in the background and switches back once it is
+// reachable again.
+type TieredStore struct {
+	l1         *MemoryStore
+	l1TTL      time.Duration
+	instanceID string
+
+	redisOpts     redisclient.Options
+	retryInterval time.Duration
+
+	mu      sync.RWMutex
+	l2      *RedisStore
+	pending map[string]struct{} // deletes that could not reach Redis
+
+	healthy      atomic.Bool
+	reconnecting atomic.Bool
+	closed       chan struct{}
+	closeOnce    sync.Once
+}
+
+// NewTieredStore creates a tiered store backed by memory and Redis
+// l1TTL caps how long entries stay in memory, which bounds how long writes
+// made by other instances stay invisible; with an l1TTL of zero, memory is only
+// read while Redis is unavailable. If Redis cannot be reached the store starts
+// in memory-only mode and keeps trying to connect.
+func NewTieredStore(l1 *MemoryStore, l1TTL time.Duration, redisOpts redisclient.Options) *TieredStore {
+	t := &TieredStore{
+		l1:            l1,
+		l1TTL:         l1TTL,
+		instanceID:    newInstanceID(),
+		redisOpts:     redisOpts,
+		retryInterval: reconnectInterval,
+		pending:       make(map[string]struct{}),
+		closed:        make(chan struct{}),
+	}
+	metrics.CacheLayerUp.WithLabelValues("L1").Set(1)
+
+	l2, err := NewRedisStore(redisOpts)
+	if err != nil {
+		t.markUnhealthy(err)
+		return t
+	}
+
+	t.l2 = l2
+	t.healthy.Store(true)
+	go t.subscribe(l2)
+	metrics.CacheLayerUp.WithLabelValues("L2").Set(1)
+	log.Info().Msg("Connected to Redis")
+
+	return t
+}
+
+// Get retrieves cached data, trying memory before Redis
+func (t *TieredStore) Get(ctx context.Context, walletAddress string) (*auth.WalletAuth, error) {
+	if t.readL1() {
+		if data, err := t.l1.Get(ctx, walletAddress); err == nil {
+			return data, nil
+		}
+	}
+
+	l2 := t.redis()
+	if l2 == nil {
+		return nil, errNotFound
+	}
+
+	data, err := l2.Get(ctx, walletAddress)
+	if err != nil {
+		if !errors.Is(err, errNotFound) {
+			t.fail(ctx, "get", err)
+		}
+		return nil, err
+	}
+
+	// Back-fill L1 so the next lookup stays in memory
+	if t.l1TTL > 0 {
+		_ = t.l1.Set(ctx, walletAddress, data, t.l1TTL)
+	}
+	return data, nil
+}
+
+// Set stores data in both layers
+func (t *TieredStore) Set(ctx context.Context, walletAddress string, data *auth.WalletAuth, ttl time.Duration) error {
+	_ = t.l1.Set(ctx, walletAddress, data, t.memoryTTL(ttl))
+
+	if l2 := t.redis(); l2 != nil {
+		if err := l2.Set(ctx, walletAddress, data, ttl); err != nil {
+			t.fail(ctx, "set", err)
+		}
+	}
+	return nil
+}
+
+// Delete removes data from both layers
+// Deletes that cannot reach Redis are replayed once it is back, so it never
+// serves an entry that was invalidated during the outage.
+func (t *TieredStore) Delete(ctx context.Context, walletAddress string) error {
+	_ = t.l1.Delete(ctx, walletAddress)
+
+	l2 := t.redis()
+	if l2 == nil {
+		t.addPending(walletAddress)
+		return nil
+	}
+
+	if err := l2.Delete(ctx, walletAddress); err != nil {
+		t.addPending(walletAddress)
+		t.fail(ctx, "delete", err)
+		return nil
+	}
+
+	if err := l2.PublishInvalidation(ctx, t.instanceID, walletAddress); err != nil {
+		t.addPending(walletAddress)
+		t.fail(ctx, "publish", err)
+	}
+	return nil
+}
+
+// BatchGet retrieves multiple entries, fetching only L1 misses from Redis
+func (t *TieredStore) BatchGet(ctx context.Context, walletAddresses []string) (map[string]*auth.WalletAuth, error) {
+	result := make(map[string]*auth.WalletAuth, len(walletAddresses))
+
+	missing := walletAddresses
+	if t.readL1() {
+		result, _ = t.l1.BatchGet(ctx, walletAddresses)
+		missing = make([]string, 0, len(walletAddresses)-len(result))
+		for _, addr := range walletAddresses {
+			if _, ok := result[addr]; !ok {
+				missing = append(missing, addr)
+			}
+		}
+	}
+
+	l2 := t.redis()
+	if l2 == nil || len(missing) == 0 {
+		return result, nil
+	}
+
+	fetched, err := l2.BatchGet(ctx, missing)
+	if err != nil {
+		t.fail(ctx, "batch_get", err)
+	}
+	for addr, data := range fetched {
+		result[addr] = data
+		if t.l1TTL > 0 {
+			_ = t.l1.Set(ctx, addr, data, t.l1TTL)
+		}
+	}
+
+	return result, nil
+}
+
+// BatchSet stores multiple entries in both layers
+func (t *TieredStore) BatchSet(ctx context.Context, data map[string]*auth.WalletAuth, ttl time.Duration) error {
+	_ = t.l1.BatchSet(ctx, data, t.memoryTTL(ttl))
+
+	if l2 := t.redis(); l2 != nil {
+		if err := l2.BatchSet(ctx, data, ttl); err != nil {
+			t.fail(ctx, "batch_set", err)
+		}
+	}
+	return nil
+}
+
+// HotWallets lists wallets cached in Redis, so a new instance can load them
+// into memory; it implements a cache warm-up source
+func (t *TieredStore) HotWallets(ctx context.Context, limit int) ([]string, error) {
+	l2 := t.redis()
+	if l2 == nil {
+		return nil, errRedisUnavailable
+	}
+
+	wallets, err := l2.Wallets(ctx, limit)
+	if err != nil {
+		t.fail(ctx, "scan", err)
+	}
+	return wallets, err
+}
+
+// HealthCheck reports the store as healthy while memory can serve requests
+// A Redis outage only degrades the cache, so it is logged and exported as a
+// metric instead of failing the service health check.
+func (t *TieredStore) HealthCheck(ctx context.Context) error {
+	if l2 := t.redis(); l2 != nil {
+		if err := l2.HealthCheck(ctx); err != nil {
+			t.fail(ctx, "health_check", err)
+		}
+	}
+	return nil
+}
+
+// Close stops reconnecting, stops the memory store and closes the Redis connection
+func (t *TieredStore) Close() error {
+	t.closeOnce.Do(func() {
+		close(t.closed)
+		t.l1.Stop()
+	})
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.l2 == nil {
+		return nil
+	}
+	return t.l2.Close()
+}
+
+// readL1 reports whether reads should try memory first
+func (t *TieredStore) readL1() bool {
+	return t.l1TTL > 0 || !t.healthy.Load()
+}
+
+// memoryTTL returns how long an entry written with ttl stays in memory
+// While Redis is unavailable memory is the only copy, so it is kept for the
+// full ttl.
+func (t *TieredStore) memoryTTL(ttl time.Duration) time.Duration {
+	if t.l1TTL > 0 && t.l1TTL < ttl && t.healthy.Load() {
+		return t.l1TTL
+	}
+	return ttl
+}
+
+// redis returns the Redis layer, or nil while it is unavailable
+func (t *TieredStore) redis() *RedisStore {
+	if !t.healthy.Load() {
+		return nil
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.l2
+}
+
+// fail records a Redis error and switches to memory-only mode
+// Errors caused by the caller's context ending say nothing about Redis.
+func (t *TieredStore) fail(ctx context.Context, operation string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	metrics.CacheErrors.WithLabelValues("L2", operation).Inc()
+	t.markUnhealthy(err)
+}
+
+// markUnhealthy switches to memory-only mode and starts reconnecting
+func (t *TieredStore) markUnhealthy(err error) {
+	t.healthy.Store(false)
+	metrics.CacheLayerUp.WithLabelValues("L2").Set(0)
+
+	if !t.reconnecting.CompareAndSwap(false, true) {
+		return
+	}
+	log.Warn().Err(err).Msg("Redis unavailable, serving cache from memory only")
+
+	go t.reconnect()
+}
+
+// reconnect retries Redis until it is reachable, then replays pending deletes
+// and switches back to tiered mode
+func (t *TieredStore) reconnect() {
+	ticker := time.NewTicker(t.retryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.closed:
+			t.reconnecting.Store(false)
+			return
+		case <-ticker.C:
+		}
+
+		if err := t.tryReconnect(); err != nil {
+			log.Debug().Err(err).Msg("Redis still unavailable")
+			continue
+		}
+
+		// Other instances' invalidations were missed while Redis was down
+		t.flushL1("reconnect")
+
+		// Clear the flag first so a failure right after switching back
+		// starts a new reconnect loop
+		t.reconnecting.Store(false)
+		t.healthy.Store(true)
+		metrics.CacheLayerUp.WithLabelValues("L2").Set(1)
+		log.Info().Msg("Reconnected to Redis")
+		return
+	}
+}
+
+// tryReconnect connects to Redis if needed and flushes pending deletes
+func (t *TieredStore) tryReconnect() error {
+	ctx, cancel := context.WithTimeout(context.Background(), t.retryInterval)
+	defer cancel()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.l2 == nil {
+		l2, err := NewRedisStore(t.redisOpts)
+		if err != nil {
+			return err
+		}
+		t.l2 = l2
+		go t.subscribe(l2)
+	}
+
+	if err := t.l2.HealthCheck(ctx); err != nil {
+		return err
+	}
+
+	for walletAddress := range t.pending {
+		if err := t.l2.Delete(ctx, walletAddress); err != nil {
+			return err
+		}
+		if err := t.l2.PublishInvalidation(ctx, t.instanceID, walletAddress); err != nil {
+			return err
+		}
+		delete(t.pending, walletAddress)
+	}
+	return nil
+}
+
+// addPending remembers a delete that still has to reach Redis
+func (t *TieredStore) addPending(walletAddress string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.pending) >= maxPendingDeletes {
+		log.Warn().Str("wallet", walletAddress).Msg("Too many pending cache deletes, Redis may serve stale entries")
+		return
+	}
+	t.pending[walletAddress] = struct{}{}
+}
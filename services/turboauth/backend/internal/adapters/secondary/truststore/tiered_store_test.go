@@ -0,0 +1,206 @@
+package truststore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/redisclient"
+
+	"github.com/alicebob/miniredis/v2"
+)
+
+// newTestTieredStore returns a tiered store in front of server that retries
+// Redis every few milliseconds
+func newTestTieredStore(t *testing.T, server *miniredis.Miniredis, l1TTL time.Duration) *TieredStore {
+	t.Helper()
+
+	store := NewTieredStore(NewMemoryStore(0, 0), l1TTL, redisclient.Options{Addrs: []string{server.Addr()}})
+	store.retryInterval = 10 * time.Millisecond
+	t.Cleanup(func() { store.Close() })
+
+	if !store.healthy.Load() {
+		t.Fatal("tiered store did not connect to Redis")
+	}
+	return store
+}
+
+// walletEntry returns a test entry for walletAddress
+func walletEntry(walletAddress string) *auth.WalletAuth {
+	entry := testEntry()
+	entry.WalletAddress = walletAddress
+	return entry
+}
+
+// waitFor polls cond until it holds or a second has passed
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// degrade stops server and makes store notice the outage
+func degrade(t *testing.T, store *TieredStore, server *miniredis.Miniredis) {
+	t.Helper()
+
+	server.Close()
+	if _, err := store.Get(context.Background(), "not-cached"); err == nil {
+		t.Fatal("Get of an uncached wallet while Redis is down: want an error")
+	}
+	if store.healthy.Load() {
+		t.Fatal("store still healthy after a failed Redis read")
+	}
+}
+
+func TestTieredStoreDegradesToMemory(t *testing.T) {
+	const l1TTL = 50 * time.Millisecond
+
+	server := miniredis.RunT(t)
+	store := newTestTieredStore(t, server, l1TTL)
+	ctx := context.Background()
+
+	degrade(t, store, server)
+
+	if err := store.HealthCheck(ctx); err != nil {
+		t.Errorf("HealthCheck while Redis is down: %v", err)
+	}
+
+	// Memory is the only copy of entries written during the outage, so they
+	// outlive the L1 TTL
+	if err := store.Set(ctx, "written", walletEntry("written"), time.Hour); err != nil {
+		t.Fatalf("Set while Redis is down: %v", err)
+	}
+	if err := store.BatchSet(ctx, map[string]*auth.WalletAuth{"batch": walletEntry("batch")}, time.Hour); err != nil {
+		t.Fatalf("BatchSet while Redis is down: %v", err)
+	}
+	time.Sleep(l1TTL + 10*time.Millisecond)
+
+	got, err := store.BatchGet(ctx, []string{"written", "batch"})
+	if err != nil {
+		t.Fatalf("BatchGet while Redis is down: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("BatchGet past the L1 TTL returned %d entries, want both written during the outage", len(got))
+	}
+}
+
+func TestTieredStoreServesMemoryDuringOutage(t *testing.T) {
+	server := miniredis.RunT(t)
+	store := newTestTieredStore(t, server, 0)
+	ctx := context.Background()
+
+	if err := store.Set(ctx, "cached", walletEntry("cached"), time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	// While Redis is up it is the only layer read
+	server.Del(store.redis().keys.Key("auth", "cached"))
+	if _, err := store.Get(ctx, "cached"); err == nil {
+		t.Error("Get of an entry deleted from Redis: want an error")
+	}
+
+	if err := store.Set(ctx, "cached", walletEntry("cached"), time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	degrade(t, store, server)
+
+	// Entries cached before the outage are served from memory
+	if _, err := store.Get(ctx, "cached"); err != nil {
+		t.Errorf("Get of an entry cached before the outage: %v", err)
+	}
+}
+
+func TestTieredStoreReconnectsAndReplaysDeletes(t *testing.T) {
+	server := miniredis.RunT(t)
+	store := newTestTieredStore(t, server, time.Minute)
+	ctx := context.Background()
+
+	for _, wallet := range []string{"deleted", "kept"} {
+		if err := store.Set(ctx, wallet, walletEntry(wallet), time.Hour); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+	}
+	key := func(walletAddress string) string { return store.l2.keys.Key("auth", walletAddress) }
+
+	degrade(t, store, server)
+
+	if err := store.Delete(ctx, "deleted"); err != nil {
+		t.Fatalf("Delete while Redis is down: %v", err)
+	}
+	if _, err := store.Get(ctx, "deleted"); err == nil {
+		t.Error("Get of an entry deleted during the outage: want an error")
+	}
+	// An entry only memory knows about is dropped once Redis is back
+	if err := store.Set(ctx, "memory-only", walletEntry("memory-only"), time.Hour); err != nil {
+		t.Fatalf("Set while Redis is down: %v", err)
+	}
+
+	if err := server.Restart(); err != nil {
+		t.Fatalf("restarting Redis: %v", err)
+	}
+	waitFor(t, "the store to reconnect", store.healthy.Load)
+
+	if server.Exists(key("deleted")) {
+		t.Error("entry deleted during the outage is still in Redis")
+	}
+	if !server.Exists(key("kept")) {
+		t.Error("entry not deleted during the outage is gone from Redis")
+	}
+	store.mu.RLock()
+	pending := len(store.pending)
+	store.mu.RUnlock()
+	if pending != 0 {
+		t.Errorf("%d deletes still pending after reconnecting", pending)
+	}
+
+	if _, err := store.Get(ctx, "kept"); err != nil {
+		t.Errorf("Get after reconnecting: %v", err)
+	}
+	if _, err := store.Get(ctx, "memory-only"); err == nil {
+		t.Error("Get of an entry written only to memory during the outage: want an error after reconnecting")
+	}
+}
+
+func TestTieredStoreDeleteInvalidatesOtherInstances(t *testing.T) {
+	server := miniredis.RunT(t)
+	deleter := newTestTieredStore(t, server, time.Minute)
+	other := newTestTieredStore(t, server, time.Minute)
+	ctx := context.Background()
+
+	channel := deleter.l2.keys.Channel(invalidationChannel)
+	waitFor(t, "both instances to subscribe", func() bool {
+		return server.PubSubNumSub(channel)[channel] == 2
+	})
+
+	for _, wallet := range []string{"deleted", "kept"} {
+		if err := deleter.Set(ctx, wallet, walletEntry(wallet), time.Hour); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+		// Reading through the other instance loads the entry into its memory
+		if _, err := other.Get(ctx, wallet); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+	}
+
+	if err := deleter.Delete(ctx, "deleted"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	waitFor(t, "the other instance to drop the deleted entry", func() bool {
+		_, err := other.l1.Get(ctx, "deleted")
+		return err != nil
+	})
+	if _, err := other.Get(ctx, "deleted"); err == nil {
+		t.Error("Get of a deleted entry through the other instance: want an error")
+	}
+	if _, err := other.l1.Get(ctx, "kept"); err != nil {
+		t.Errorf("entry not deleted was dropped from the other instance's memory: %v", err)
+	}
+}
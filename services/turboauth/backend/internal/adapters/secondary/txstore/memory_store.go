@@ -0,0 +1,123 @@
+package txstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"turboauth/internal/domain/auth"
+)
+
+// retention is how long status changes remain queryable after their last update
+const retention = time.Hour
+
+// MemoryStore implements an in-memory status change store (single instance only)
+type MemoryStore struct {
+	mu      sync.Mutex
+	changes map[string]*auth.StatusChange
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewMemoryStore creates a new in-memory status change store
+func NewMemoryStore() *MemoryStore {
+	store := &MemoryStore{
+		changes: make(map[string]*auth.StatusChange),
+		stop:    make(chan struct{}),
+	}
+
+	// Start cleanup goroutine
+	go store.cleanupExpired()
+
+	return store
+}
+
+// CreateStatusChange stores a newly submitted status change
+func (m *MemoryStore) CreateStatusChange(ctx context.Context, change *auth.StatusChange) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	copied := *change
+	m.changes[change.TxHash] = &copied
+	return nil
+}
+
+// GetStatusChange retrieves a status change by transaction hash
+func (m *MemoryStore) GetStatusChange(ctx context.Context, txHash string) (*auth.StatusChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	change, ok := m.changes[txHash]
+	if !ok {
+		return nil, auth.ErrTxNotFound
+	}
+
+	copied := *change
+	return &copied, nil
+}
+
+// PendingStatusChanges returns the status changes still awaiting confirmation
+func (m *MemoryStore) PendingStatusChanges(ctx context.Context) ([]*auth.StatusChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var pending []*auth.StatusChange
+	for _, change := range m.changes {
+		if change.State == auth.TxPending {
+			copied := *change
+			pending = append(pending, &copied)
+		}
+	}
+	return pending, nil
+}
+
+// UpdateStatusChange applies update to a stored status change under the store lock
+func (m *MemoryStore) UpdateStatusChange(ctx context.Context, txHash string, update func(*auth.StatusChange) error) (*auth.StatusChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.changes[txHash]
+	if !ok {
+		return nil, auth.ErrTxNotFound
+	}
+
+	change := *stored
+	if err := update(&change); err != nil {
+		return nil, err
+	}
+	m.changes[txHash] = &change
+
+	result := change
+	return &result, nil
+}
+
+// Stop ends the cleanup goroutine
+func (m *MemoryStore) Stop() {
+	m.stopOnce.Do(func() {
+		close(m.stop)
+	})
+}
+
+// cleanupExpired removes old status changes periodically
+func (m *MemoryStore) cleanupExpired() {
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.stop:
+			return
+		case <-ticker.C:
+		}
+
+		now := time.Now()
+		m.mu.Lock()
+		for txHash, change := range m.changes {
+			if now.After(change.UpdatedAt.Add(retention)) {
+				delete(m.changes, txHash)
+			}
+		}
+		m.mu.Unlock()
+	}
+}
@@ -0,0 +1,197 @@
+package txstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/redisclient"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// maxUpdateRetries bounds optimistic-locking retries under contention
+const maxUpdateRetries = 10
+
+// RedisStore implements a Redis-based status change store shared by all instances
+//
+// Each status change is stored under its transaction hash until its retention
+// has passed. A set indexes the hashes of pending changes; members whose
+// change has expired are pruned when the set is read.
+type RedisStore struct {
+	client redis.UniversalClient
+	keys   redisclient.Keyspace
+}
+
+// NewRedisStore creates a new Redis status change store
+func NewRedisStore(opts redisclient.Options) (*RedisStore, error) {
+	client, err := redisclient.New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RedisStore{client: client, keys: opts.Keyspace()}, nil
+}
+
+// CreateStatusChange stores a newly submitted status change
+func (r *RedisStore) CreateStatusChange(ctx context.Context, change *auth.StatusChange) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, r.key(change.TxHash), data, ttl(change))
+		if change.State == auth.TxPending {
+			pipe.SAdd(ctx, r.pendingKey(), change.TxHash)
+		}
+		return nil
+	})
+	return err
+}
+
+// GetStatusChange retrieves a status change by transaction hash
+func (r *RedisStore) GetStatusChange(ctx context.Context, txHash string) (*auth.StatusChange, error) {
+	data, err := r.client.Get(ctx, r.key(txHash)).Bytes()
+	if err == redis.Nil {
+		return nil, auth.ErrTxNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var change auth.StatusChange
+	if err := json.Unmarshal(data, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// PendingStatusChanges returns the status changes still awaiting confirmation
+func (r *RedisStore) PendingStatusChanges(ctx context.Context) ([]*auth.StatusChange, error) {
+	txHashes, err := r.client.SMembers(ctx, r.pendingKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(txHashes) == 0 {
+		return nil, nil
+	}
+
+	// Pipelined GETs rather than MGET: in cluster mode the changes live in
+	// different slots, and the pipeline is split by node
+	cmds := make([]*redis.StringCmd, len(txHashes))
+	_, err = r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, txHash := range txHashes {
+			cmds[i] = pipe.Get(ctx, r.key(txHash))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	var pending []*auth.StatusChange
+	var stale []interface{}
+	for i, cmd := range cmds {
+		data, err := cmd.Bytes()
+		if err != nil {
+			stale = append(stale, txHashes[i]) // expired
+			continue
+		}
+
+		var change auth.StatusChange
+		if err := json.Unmarshal(data, &change); err != nil || change.State != auth.TxPending {
+			stale = append(stale, txHashes[i])
+			continue
+		}
+		pending = append(pending, &change)
+	}
+
+	if len(stale) > 0 {
+		if err := r.client.SRem(ctx, r.pendingKey(), stale...).Err(); err != nil {
+			return nil, err
+		}
+	}
+	return pending, nil
+}
+
+// UpdateStatusChange applies update to a stored status change using
+// optimistic locking, retrying if another instance changed it concurrently
+func (r *RedisStore) UpdateStatusChange(ctx context.Context, txHash string, update func(*auth.StatusChange) error) (*auth.StatusChange, error) {
+	key := r.key(txHash)
+
+	var result *auth.StatusChange
+	txf := func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return auth.ErrTxNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		var change auth.StatusChange
+		if err := json.Unmarshal(data, &change); err != nil {
+			return err
+		}
+		if err := update(&change); err != nil {
+			return err
+		}
+
+		data, err = json.Marshal(&change)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, ttl(&change))
+			return nil
+		})
+		if err == nil {
+			result = &change
+		}
+		return err
+	}
+
+	for i := 0; i < maxUpdateRetries; i++ {
+		err := r.client.Watch(ctx, txf, key)
+		if errors.Is(err, redis.TxFailedErr) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// The pending index lives in another slot in cluster mode, so it is
+		// updated outside the transaction; a stale member is pruned on read
+		if result.State != auth.TxPending {
+			if err := r.client.SRem(ctx, r.pendingKey(), txHash).Err(); err != nil {
+				return nil, err
+			}
+		}
+		return result, nil
+	}
+
+	return nil, fmt.Errorf("status change %s: too much contention", txHash)
+}
+
+// Close closes the Redis connection
+func (r *RedisStore) Close() error {
+	return r.client.Close()
+}
+
+// ttl keeps a status change until its retention after the last update has passed
+func ttl(change *auth.StatusChange) time.Duration {
+	return time.Until(change.UpdatedAt.Add(retention))
+}
+
+func (r *RedisStore) key(txHash string) string {
+	return r.keys.Key("status_change", txHash)
+}
+
+func (r *RedisStore) pendingKey() string {
+	return r.keys.Key("status_changes", "pending")
+}
@@ -2,15 +2,19 @@ package wallet
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
-	"regexp"
-	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/identity"
+	"turboauth/pkg/schnorrq"
+
+	"github.com/cloudflare/circl/xof/k12"
 )
 
 // Verifier implements wallet signature verification
-type Verifier struct {
-	// TODO: Add Qubic-specific crypto libraries
-}
+type Verifier struct{}
 
 // NewVerifier creates a new wallet verifier
 func NewVerifier() *Verifier {
@@ -18,35 +22,56 @@ func NewVerifier() *Verifier {
 }
 
 // VerifySignature verifies a wallet signature
-// TODO: Implement actual Qubic signature verification
+// Qubic signatures are SchnorrQ (FourQ) signatures over the 32-byte K12
+// digest of the message, checked against the public key encoded in the
+// wallet identity. The signature may be hex or base64 encoded.
 func (v *Verifier) VerifySignature(ctx context.Context, walletAddress, message, signature string) (bool, error) {
-	// Placeholder implementation
-	// In production, this would use Qubic's cryptographic libraries
-	// to verify the signature against the wallet's public key
-
 	if walletAddress == "" || message == "" || signature == "" {
 		return false, fmt.Errorf("missing required parameters")
 	}
 
-	// TODO: Implement actual signature verification using Qubic crypto
-	// For now, return true for testing purposes
-	return true, nil
-}
+	publicKey, err := identity.PublicKey(walletAddress)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", auth.ErrInvalidAddress, err)
+	}
+
+	sig, ok := decodeSignature(signature)
+	if !ok {
+		return false, nil
+	}
 
-// GenerateChallenge generates a challenge message for wallet verification
-func (v *Verifier) GenerateChallenge(walletAddress string) string {
-	timestamp := time.Now().Unix()
-	return fmt.Sprintf("Sign this message to verify your wallet: %s at %d", walletAddress, timestamp)
+	return schnorrq.Verify(publicKey, messageDigest(message), sig), nil
 }
 
 // ValidateAddress checks if a wallet address is valid
-// Qubic addresses are 60 uppercase characters (A-Z)
+// Qubic addresses are 60 uppercase characters (A-Z): 56 encoding the public
+// key and a 4-character K12 checksum, which must match
 func (v *Verifier) ValidateAddress(walletAddress string) bool {
-	if len(walletAddress) != 60 {
-		return false
+	return identity.Validate(walletAddress) == nil
+}
+
+// messageDigest returns the K12 digest that wallets sign for a message
+func messageDigest(message string) [schnorrq.DigestSize]byte {
+	h := k12.NewDraft10(nil)
+	_, _ = h.Write([]byte(message))
+
+	var digest [schnorrq.DigestSize]byte
+	_, _ = h.Read(digest[:])
+	return digest
+}
+
+// decodeSignature decodes a hex or base64 encoded 64-byte signature
+func decodeSignature(signature string) ([schnorrq.SignatureSize]byte, bool) {
+	var sig [schnorrq.SignatureSize]byte
+
+	raw, err := hex.DecodeString(signature)
+	if err != nil {
+		raw, err = base64.StdEncoding.DecodeString(signature)
+	}
+	if err != nil || len(raw) != schnorrq.SignatureSize {
+		return sig, false
 	}
 
-	// Qubic addresses are uppercase A-Z only
-	matched, _ := regexp.MatchString("^[A-Z]{60}$", walletAddress)
-	return matched
+	copy(sig[:], raw)
+	return sig, true
 }
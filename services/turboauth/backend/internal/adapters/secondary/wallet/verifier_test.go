@@ -0,0 +1,138 @@
+package wallet
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"turboauth/internal/domain/auth"
+)
+
+// The test vector is the identity of the 55-letter seed "aaa…a" signing
+// vectorMessage
+const (
+	vectorIdentity  = "BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK"
+	vectorMessage   = "TurboAuth test vector"
+	vectorSignature = "3878d3c910bafea9485d8d685c250c064da6a920e4d4afc15e8bc40bec78019c" +
+		"829ef09c1f68bc7974c8ee2370a797bcb0466439c386f7c990887050246b0800"
+
+	// unreducedSignature is the vector signature with s + N in place of s
+	unreducedSignature = "3878d3c910bafea9485d8d685c250c064da6a920e4d4afc15e8bc40bec78019c" +
+		"692b67642ebc6ea90d42fe21bea7549c965b20d6451e4bba0393ce9ee5363200"
+)
+
+// flipBit flips one bit of a hex encoded signature
+func flipBit(signature string, index int) string {
+	raw, _ := hex.DecodeString(signature)
+	raw[index] ^= 0x01
+	return hex.EncodeToString(raw)
+}
+
+func TestVerifySignature(t *testing.T) {
+	raw, _ := hex.DecodeString(vectorSignature)
+
+	tests := []struct {
+		name      string
+		wallet    string
+		message   string
+		signature string
+		want      bool
+		wantErr   error
+	}{
+		{
+			name:      "hex signature",
+			wallet:    vectorIdentity,
+			message:   vectorMessage,
+			signature: vectorSignature,
+			want:      true,
+		},
+		{
+			name:      "base64 signature",
+			wallet:    vectorIdentity,
+			message:   vectorMessage,
+			signature: base64.StdEncoding.EncodeToString(raw),
+			want:      true,
+		},
+		{
+			name:      "tampered message",
+			wallet:    vectorIdentity,
+			message:   vectorMessage + ".",
+			signature: vectorSignature,
+		},
+		{
+			name:      "tampered R",
+			wallet:    vectorIdentity,
+			message:   vectorMessage,
+			signature: flipBit(vectorSignature, 0),
+		},
+		{
+			name:      "tampered s",
+			wallet:    vectorIdentity,
+			message:   vectorMessage,
+			signature: flipBit(vectorSignature, 32),
+		},
+		{
+			name:      "non-canonical scalar",
+			wallet:    vectorIdentity,
+			message:   vectorMessage,
+			signature: unreducedSignature,
+		},
+		{
+			name:      "truncated signature",
+			wallet:    vectorIdentity,
+			message:   vectorMessage,
+			signature: vectorSignature[:126],
+		},
+		{
+			name:      "other wallet",
+			wallet:    "AFZPUAIYVPNUYGJRQVLUKOPPVLHAZQTGLYAAUUNBXFTVTAMSBKQBLEIEPCVJ",
+			message:   vectorMessage,
+			signature: vectorSignature,
+		},
+		{
+			name:      "bad identity checksum",
+			wallet:    vectorIdentity[:59] + "A",
+			message:   vectorMessage,
+			signature: vectorSignature,
+			wantErr:   auth.ErrInvalidAddress,
+		},
+	}
+
+	verifier := NewVerifier()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := verifier.VerifySignature(context.Background(), tt.wallet, tt.message, tt.signature)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("VerifySignature error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("VerifySignature = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAddress(t *testing.T) {
+	tests := []struct {
+		name   string
+		wallet string
+		want   bool
+	}{
+		{name: "valid", wallet: vectorIdentity, want: true},
+		{name: "bad checksum", wallet: vectorIdentity[:59] + "A"},
+		{name: "lowercase", wallet: "bzbqfllbncxemglobhuvftluplvcpquassilfaboffbcadqssupnwlzbqexk"},
+		{name: "too short", wallet: vectorIdentity[:56]},
+		{name: "empty", wallet: ""},
+	}
+
+	verifier := NewVerifier()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifier.ValidateAddress(tt.wallet); got != tt.want {
+				t.Errorf("ValidateAddress(%q) = %v, want %v", tt.wallet, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package warmup
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"strings"
+)
+
+// FileSource lists wallets from a text file with one address per line
+// Blank lines and lines starting with '#' are ignored.
+type FileSource struct {
+	path string
+}
+
+// NewFileSource creates a warm-up source reading path
+func NewFileSource(path string) *FileSource {
+	return &FileSource{path: path}
+}
+
+// HotWallets returns the addresses in the file, in file order
+func (f *FileSource) HotWallets(ctx context.Context, limit int) ([]string, error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var wallets []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if limit > 0 && len(wallets) >= limit {
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		wallets = append(wallets, line)
+	}
+
+	return wallets, scanner.Err()
+}
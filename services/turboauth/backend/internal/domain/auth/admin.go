@@ -0,0 +1,160 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// maxAdminNonceLength bounds the nonce an admin may choose
+const maxAdminNonceLength = 128
+
+// AdminPolicy configures who may change wallet statuses
+type AdminPolicy struct {
+	// Identities are the admin wallet identities allowed to sign status changes
+	Identities []string
+
+	// MaxRequestAge is how far a request timestamp may be from the current time
+	MaxRequestAge time.Duration
+
+	// ApprovalThreshold is how many admins must approve a proposal before it
+	// is submitted; values below 2 let a single admin change any status
+	ApprovalThreshold int
+
+	// ApprovalStatuses are the statuses that can only be set through an
+	// approved proposal once ApprovalThreshold is above 1; empty means all
+	ApprovalStatuses []AuthStatus
+
+	// ProposalTTL is how long a proposal can collect approvals
+	ProposalTTL time.Duration
+}
+
+// SigningMessage returns the canonical text an admin signs to authorize the
+// status change:
+//
+//	TurboAuth SetStatus
+//	Wallet: BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK
+//	Status: BLOCKED
+//	Trust Score: 0
+//	Nonce: 7d1f0c2e
+//	Timestamp: 1735732800
+func (r *SetStatusRequest) SigningMessage() string {
+	return r.signingMessage("TurboAuth SetStatus")
+}
+
+// ProposalMessage returns the canonical text an admin signs to propose the
+// status change; it differs from SigningMessage only in its header, so a
+// proposal signature cannot be replayed as a direct status change:
+//
+//	TurboAuth ProposeStatus
+//	Wallet: BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK
+//	Status: BLOCKED
+//	Trust Score: 0
+//	Nonce: 7d1f0c2e
+//	Timestamp: 1735732800
+func (r *SetStatusRequest) ProposalMessage() string {
+	return r.signingMessage("TurboAuth ProposeStatus")
+}
+
+// signingMessage formats the requested change under header
+func (r *SetStatusRequest) signingMessage(header string) string {
+	return fmt.Sprintf("%s\nWallet: %s\nStatus: %s\nTrust Score: %d\nNonce: %s\nTimestamp: %d",
+		header, r.WalletAddress, r.Status, r.TrustScore, r.Nonce, r.Timestamp)
+}
+
+// validate checks the requested change is one the contract accepts
+func (r *SetStatusRequest) validate() error {
+	switch r.Status {
+	case StatusActive, StatusBlocked, StatusReview:
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidStatus, r.Status)
+	}
+	if r.TrustScore < 0 || r.TrustScore > 100 {
+		return ErrInvalidTrustScore
+	}
+	return nil
+}
+
+// Validate checks the policy can be satisfied: a proposal can never collect
+// more approvals than there are admins
+func (p AdminPolicy) Validate() error {
+	if p.ApprovalThreshold > 1 && p.ApprovalThreshold > len(p.Identities) {
+		return fmt.Errorf("approval threshold %d exceeds the %d configured admins", p.ApprovalThreshold, len(p.Identities))
+	}
+	return nil
+}
+
+// isAdmin reports whether identity is a registered admin
+func (p AdminPolicy) isAdmin(identity string) bool {
+	for _, admin := range p.Identities {
+		if admin == identity {
+			return true
+		}
+	}
+	return false
+}
+
+// threshold returns the number of approvals a proposal needs
+func (p AdminPolicy) threshold() int {
+	if p.ApprovalThreshold < 1 {
+		return 1
+	}
+	return p.ApprovalThreshold
+}
+
+// requiresApproval reports whether status can only be set through a proposal
+func (p AdminPolicy) requiresApproval(status AuthStatus) bool {
+	if p.threshold() < 2 {
+		return false
+	}
+	if len(p.ApprovalStatuses) == 0 {
+		return true
+	}
+	for _, s := range p.ApprovalStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// authorizeAdmin checks that a status change was signed by a registered admin
+// and has not been submitted before
+func (s *Service) authorizeAdmin(ctx context.Context, req *SetStatusRequest) error {
+	return s.authorizeAdminSignature(ctx, req.AdminAddress, req.Nonce, req.Timestamp, req.AdminSignature, req.SigningMessage())
+}
+
+// authorizeAdminSignature checks that message was signed by a registered admin
+// recently, and claims the nonce so the signature cannot be replayed
+func (s *Service) authorizeAdminSignature(ctx context.Context, admin, nonce string, timestamp int64, signature, message string) error {
+	if !s.adminPolicy.isAdmin(admin) {
+		return fmt.Errorf("%w: %s is not an admin", ErrUnauthorized, admin)
+	}
+
+	if nonce == "" || len(nonce) > maxAdminNonceLength || strings.ContainsAny(nonce, "\r\n") {
+		return fmt.Errorf("%w: invalid nonce", ErrUnauthorized)
+	}
+
+	age := time.Since(time.Unix(timestamp, 0))
+	if age > s.adminPolicy.MaxRequestAge || age < -s.adminPolicy.MaxRequestAge {
+		return fmt.Errorf("%w: request timestamp outside the allowed window", ErrUnauthorized)
+	}
+
+	verified, err := s.walletPort.VerifySignature(ctx, admin, message, signature)
+	if err != nil || !verified {
+		return fmt.Errorf("%w: invalid admin signature", ErrUnauthorized)
+	}
+
+	// Requests older than MaxRequestAge are rejected above, so the nonce only
+	// has to be remembered for the rest of the window on either side of now
+	claimed, err := s.noncePort.ClaimNonce(ctx, "admin:"+admin+":"+nonce, 2*s.adminPolicy.MaxRequestAge)
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		return fmt.Errorf("%w: nonce already used", ErrUnauthorized)
+	}
+
+	return nil
+}
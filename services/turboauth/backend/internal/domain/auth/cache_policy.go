@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"turboauth/pkg/metrics"
+
+	"github.com/rs/zerolog/log"
+)
+
+// revalidateTimeout bounds a background refresh of a stale entry
+const revalidateTimeout = 10 * time.Second
+
+// defaultLookupTimeout bounds a shared blockchain lookup when the policy sets
+// no LookupTimeout
+const defaultLookupTimeout = 30 * time.Second
+
+// CachePolicy configures how long wallet statuses are cached
+type CachePolicy struct {
+	// TTL is how long a cached status is considered fresh
+	TTL time.Duration
+
+	// HardTTL enables stale-while-revalidate when it is greater than TTL:
+	// entries are kept until HardTTL, served immediately once older than TTL
+	// while a background refresh runs, and served flagged as stale if the
+	// refresh fails
+	HardTTL time.Duration
+
+	// NegativeTTL is how long a wallet the contract does not know is
+	// remembered as unknown; zero disables negative caching
+	NegativeTTL time.Duration
+
+	// LookupTimeout bounds a blockchain lookup shared by concurrent callers,
+	// which outlives the context of the caller that started it
+	LookupTimeout time.Duration
+}
+
+// lookupTimeout returns how long a shared blockchain lookup may run
+func (p CachePolicy) lookupTimeout() time.Duration {
+	if p.LookupTimeout <= 0 {
+		return defaultLookupTimeout
+	}
+	return p.LookupTimeout
+}
+
+// staleEnabled reports whether entries may be served after TTL
+func (p CachePolicy) staleEnabled() bool {
+	return p.HardTTL > p.TTL
+}
+
+// storeTTL returns how long entries are kept in the trust store
+func (p CachePolicy) storeTTL() time.Duration {
+	if p.staleEnabled() {
+		return p.HardTTL
+	}
+	return p.TTL
+}
+
+// negativeEntry returns the cache entry recording that a wallet is unknown
+func negativeEntry(walletAddress string) *WalletAuth {
+	return &WalletAuth{
+		WalletAddress: walletAddress,
+		Status:        StatusUnknown,
+		FetchedAt:     time.Now(),
+	}
+}
+
+// isNegative reports whether a cached status records an unknown wallet
+// Real entries are never UNKNOWN: the blockchain adapter reports those as
+// ErrWalletNotFound.
+func isNegative(status *WalletAuth) bool {
+	return status.Status == StatusUnknown
+}
+
+// softExpired reports whether a cached status is past its fresh TTL
+func (p CachePolicy) softExpired(status *WalletAuth, now time.Time) bool {
+	return p.staleEnabled() && !status.FetchedAt.IsZero() && now.Sub(status.FetchedAt) > p.TTL
+}
+
+// serveCached returns a cached status, refreshing it in the background once
+// it is past its fresh TTL. Until a refresh succeeds such a status is served
+// flagged as stale.
+func (s *Service) serveCached(status *WalletAuth) *WalletAuth {
+	if !s.cachePolicy.softExpired(status, time.Now()) {
+		return status
+	}
+
+	if status.Stale {
+		metrics.CacheStaleServed.WithLabelValues("error").Inc()
+	} else {
+		metrics.CacheStaleServed.WithLabelValues("revalidate").Inc()
+	}
+	s.revalidate(status)
+
+	stale := *status
+	stale.Stale = true
+	return &stale
+}
+
+// revalidate refreshes a soft-expired status from the blockchain in the
+// background. If the chain cannot be reached the cached entry is kept until
+// its hard TTL and marked stale, so it is served with the stale flag.
+func (s *Service) revalidate(cached *WalletAuth) {
+	walletAddress := cached.WalletAddress
+	if _, running := s.revalidating.LoadOrStore(walletAddress, struct{}{}); running {
+		return
+	}
+
+	go func() {
+		defer s.revalidating.Delete(walletAddress)
+
+		ctx, cancel := context.WithTimeout(context.Background(), revalidateTimeout)
+		defer cancel()
+
+		_, err := s.lookupStatus(ctx, walletAddress)
+		if err == nil {
+			return
+		}
+
+		if errors.Is(err, ErrWalletNotFound) {
+			// The chain answered, the wallet is just gone; lookupStatus has
+			// already replaced the entry if negative caching is enabled
+			if s.cachePolicy.NegativeTTL <= 0 {
+				_ = s.trustStorePort.Delete(ctx, walletAddress)
+			}
+			return
+		}
+
+		remaining := s.cachePolicy.HardTTL - time.Since(cached.FetchedAt)
+		if remaining <= 0 {
+			return
+		}
+
+		stale := *cached
+		stale.Stale = true
+		if err := s.trustStorePort.Set(ctx, walletAddress, &stale, remaining); err != nil {
+			log.Warn().Err(err).Msg("Failed to cache status")
+		}
+
+		log.Warn().
+			Err(err).
+			Str("wallet", walletAddress).
+			Dur("remaining", remaining).
+			Msg("Revalidation failed, serving stale status")
+	}()
+}
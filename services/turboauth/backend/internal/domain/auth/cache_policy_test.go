@@ -0,0 +1,161 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/identity"
+	"turboauth/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// staleServed returns how often a stale status was served for reason
+func staleServed(reason string) float64 {
+	return testutil.ToFloat64(metrics.CacheStaleServed.WithLabelValues(reason))
+}
+
+// getStatusUntil polls GetStatus until done accepts the result, checking
+// each result with check
+func getStatusUntil(t *testing.T, svc *auth.Service, check, done func(*auth.WalletAuth) bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		status, err := svc.GetStatus(context.Background(), testWallet)
+		if err != nil {
+			t.Fatalf("GetStatus: %v", err)
+		}
+		if !check(status) {
+			t.Fatalf("GetStatus = %+v", status)
+		}
+		if done(status) {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GetStatus = %+v after %v", status, 2*time.Second)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSoftExpiredStatusIsServedStale(t *testing.T) {
+	const ttl = 50 * time.Millisecond
+
+	qubic := newFakeQubic()
+	qubic.setStatus(testWallet, auth.StatusActive, 80)
+	svc := newTestService(t, qubic, auth.WithCachePolicy(auth.CachePolicy{TTL: ttl, HardTTL: time.Hour}))
+	ctx := context.Background()
+
+	status, err := svc.GetStatus(ctx, testWallet)
+	if err != nil {
+		t.Fatalf("GetStatus: %v", err)
+	}
+	if status.Stale {
+		t.Fatal("status fetched from the blockchain is stale")
+	}
+
+	qubic.fail(errors.New("node unreachable"))
+	time.Sleep(ttl + 10*time.Millisecond)
+
+	// The first hit past the TTL starts a refresh and is already stale
+	revalidated, failed := staleServed("revalidate"), staleServed("error")
+	status, err = svc.GetStatus(ctx, testWallet)
+	if err != nil {
+		t.Fatalf("GetStatus past the TTL: %v", err)
+	}
+	if !status.Stale || status.Status != auth.StatusActive {
+		t.Errorf("GetStatus past the TTL = %+v, want the cached status flagged stale", status)
+	}
+	if got := staleServed("revalidate") - revalidated; got != 1 {
+		t.Errorf("stale served for revalidate = %v, want 1", got)
+	}
+
+	// Once the refresh has failed, hits are counted as served on error
+	stale := func(status *auth.WalletAuth) bool { return status.Stale }
+	getStatusUntil(t, svc, stale, func(*auth.WalletAuth) bool {
+		return staleServed("error") > failed
+	})
+
+	// A successful refresh clears the flag
+	qubic.fail(nil)
+	time.Sleep(ttl + 10*time.Millisecond)
+	getStatusUntil(t, svc, func(*auth.WalletAuth) bool { return true }, func(status *auth.WalletAuth) bool {
+		return !status.Stale
+	})
+}
+
+func TestUnknownWalletsAreCachedForNegativeTTL(t *testing.T) {
+	const negativeTTL = 50 * time.Millisecond
+
+	qubic := newFakeQubic()
+	svc := newTestService(t, qubic, auth.WithCachePolicy(auth.CachePolicy{TTL: time.Minute, NegativeTTL: negativeTTL}))
+	ctx := context.Background()
+
+	if _, err := svc.GetStatus(ctx, otherWallet); !errors.Is(err, auth.ErrWalletNotFound) {
+		t.Fatalf("GetStatus of an unknown wallet: error = %v, want %v", err, auth.ErrWalletNotFound)
+	}
+
+	// The wallet is registered, but the miss is remembered until NegativeTTL
+	qubic.setStatus(otherWallet, auth.StatusActive, 80)
+	if _, err := svc.GetStatus(ctx, otherWallet); !errors.Is(err, auth.ErrWalletNotFound) {
+		t.Errorf("GetStatus within the negative TTL: error = %v, want %v", err, auth.ErrWalletNotFound)
+	}
+	if got := qubic.lookups(); got != 1 {
+		t.Errorf("blockchain queries within the negative TTL = %d, want 1", got)
+	}
+
+	time.Sleep(negativeTTL + 10*time.Millisecond)
+
+	status, err := svc.GetStatus(ctx, otherWallet)
+	if err != nil || status.Status != auth.StatusActive {
+		t.Errorf("GetStatus after the negative TTL = %+v, %v, want the registered status", status, err)
+	}
+	if got := qubic.lookups(); got != 2 {
+		t.Errorf("blockchain queries after the negative TTL = %d, want 2", got)
+	}
+}
+
+func TestBatchLookupsCacheUnknownWallets(t *testing.T) {
+	unknownWallet := identity.FromPublicKey([identity.PublicKeySize]byte{0x03}, false)
+
+	tests := []struct {
+		name        string
+		negativeTTL time.Duration
+		wantQueries int
+	}{
+		{name: "negative caching", negativeTTL: time.Minute, wantQueries: 2},
+		{name: "negative caching disabled", negativeTTL: 0, wantQueries: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qubic := newFakeQubic()
+			qubic.setStatus(testWallet, auth.StatusActive, 80)
+			svc := newTestService(t, qubic, auth.WithCachePolicy(auth.CachePolicy{TTL: time.Minute, NegativeTTL: tt.negativeTTL}))
+			ctx := context.Background()
+
+			// Both wallets are queried by the first batch, and only the unknown
+			// one again by later lookups unless its miss was cached
+			for round := 0; round < 2; round++ {
+				results, err := svc.BatchGetStatus(ctx, []string{testWallet, unknownWallet})
+				if err != nil {
+					t.Fatalf("BatchGetStatus: %v", err)
+				}
+				if len(results) != 2 || results[0].Err != nil || !errors.Is(results[1].Err, auth.ErrWalletNotFound) {
+					t.Fatalf("BatchGetStatus round %d = %+v, want the known wallet and a not-found error", round, results)
+				}
+			}
+			if _, err := svc.GetStatus(ctx, unknownWallet); !errors.Is(err, auth.ErrWalletNotFound) {
+				t.Errorf("GetStatus after a batch: error = %v, want %v", err, auth.ErrWalletNotFound)
+			}
+
+			if got := qubic.lookups(); got != tt.wantQueries {
+				t.Errorf("blockchain queries = %d, want %d", got, tt.wantQueries)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"turboauth/pkg/metrics"
+
+	"github.com/rs/zerolog/log"
+)
+
+// lookupGroup deduplicates concurrent blockchain lookups of the same wallet
+// It works like singleflight, except that one caller can own the lookups of
+// several wallets at once, so a batch query and single lookups share results.
+type lookupGroup struct {
+	mu    sync.Mutex
+	calls map[string]*lookupCall
+}
+
+// lookupCall is an in-flight blockchain lookup of one wallet
+type lookupCall struct {
+	done   chan struct{}
+	status *WalletAuth
+	err    error
+}
+
+func newLookupGroup() *lookupGroup {
+	return &lookupGroup{
+		calls: make(map[string]*lookupCall),
+	}
+}
+
+// claim registers a lookup for every wallet that is not already being looked
+// up. The caller must resolve every owned call with finish; joined calls are
+// owned by other callers and only need to be waited on.
+func (g *lookupGroup) claim(walletAddresses []string) (owned, joined map[string]*lookupCall) {
+	owned = make(map[string]*lookupCall)
+	joined = make(map[string]*lookupCall)
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, addr := range walletAddresses {
+		if _, ok := owned[addr]; ok {
+			continue
+		}
+		if call, ok := g.calls[addr]; ok {
+			joined[addr] = call
+			continue
+		}
+		call := &lookupCall{done: make(chan struct{})}
+		g.calls[addr] = call
+		owned[addr] = call
+	}
+	return owned, joined
+}
+
+// finish publishes the result of an owned lookup to everyone waiting on it
+func (g *lookupGroup) finish(walletAddress string, call *lookupCall, status *WalletAuth, err error) {
+	g.mu.Lock()
+	delete(g.calls, walletAddress)
+	g.mu.Unlock()
+
+	call.status = status
+	call.err = err
+	close(call.done)
+}
+
+// wait blocks until the lookup finishes or ctx ends
+func (c *lookupCall) wait(ctx context.Context) (*WalletAuth, error) {
+	select {
+	case <-c.done:
+		return c.status, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// lookupStatus queries the blockchain for a wallet and caches the result,
+// sharing the query with concurrent lookups of the same wallet
+func (s *Service) lookupStatus(ctx context.Context, walletAddress string) (*WalletAuth, error) {
+	owned, joined := s.lookups.claim([]string{walletAddress})
+	if call, ok := joined[walletAddress]; ok {
+		metrics.BlockchainRequestsCoalesced.WithLabelValues("get_status").Inc()
+		return call.wait(ctx)
+	}
+
+	// Other callers may be waiting on this lookup, so it must not be cut
+	// short by this caller's context; it still needs a deadline of its own
+	lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.cachePolicy.lookupTimeout())
+	defer cancel()
+
+	start := time.Now()
+	log.Debug().Str("wallet", walletAddress).Msg("Querying blockchain")
+	status, err := s.qubicPort.GetAuthStatus(lookupCtx, walletAddress)
+	if errors.Is(err, ErrWalletNotFound) {
+		metrics.BlockchainRequestsTotal.WithLabelValues("get_status", "not_found").Inc()
+
+		// Remember unknown wallets briefly so repeated lookups stay off the node
+		if s.cachePolicy.NegativeTTL > 0 {
+			if err := s.trustStorePort.Set(lookupCtx, walletAddress, negativeEntry(walletAddress), s.cachePolicy.NegativeTTL); err != nil {
+				log.Warn().Err(err).Msg("Failed to cache unknown wallet")
+			}
+		}
+	} else if err != nil {
+		metrics.BlockchainRequestsTotal.WithLabelValues("get_status", "error").Inc()
+	} else {
+		metrics.BlockchainRequestsTotal.WithLabelValues("get_status", "success").Inc()
+		metrics.BlockchainRequestDuration.WithLabelValues("get_status").Observe(time.Since(start).Seconds())
+
+		// Cache for next time
+		status.FetchedAt = time.Now()
+		if err := s.trustStorePort.Set(lookupCtx, walletAddress, status, s.cachePolicy.storeTTL()); err != nil {
+			log.Warn().Err(err).Msg("Failed to cache status")
+		}
+	}
+
+	s.lookups.finish(walletAddress, owned[walletAddress], status, err)
+	return status, err
+}
+
+// lookupStatuses queries the blockchain for several wallets in one call and
+// caches the results, joining lookups of the same wallets already in flight.
+// It returns a result for every wallet; wallets the contract does not know
+// are cached as unknown like single lookups.
+func (s *Service) lookupStatuses(ctx context.Context, walletAddresses []string) map[string]StatusResult {
+	owned, joined := s.lookups.claim(walletAddresses)
+	results := make(map[string]StatusResult, len(walletAddresses))
+
+	if len(owned) > 0 {
+		addrs := make([]string, 0, len(owned))
+		for _, addr := range walletAddresses {
+			if _, ok := owned[addr]; ok {
+				addrs = append(addrs, addr)
+			}
+		}
+
+		lookupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.cachePolicy.lookupTimeout())
+		defer cancel()
+
+		start := time.Now()
+		fetched, err := s.qubicPort.BatchGetAuthStatus(lookupCtx, addrs)
+		if err != nil {
+			metrics.BlockchainRequestsTotal.WithLabelValues("batch_get_status", "error").Inc()
+			for addr, call := range owned {
+				results[addr] = StatusResult{WalletAddress: addr, Err: err}
+				s.lookups.finish(addr, call, nil, err)
+			}
+		} else {
+			metrics.BlockchainRequestsTotal.WithLabelValues("batch_get_status", "success").Inc()
+			metrics.BlockchainRequestDuration.WithLabelValues("batch_get_status").Observe(time.Since(start).Seconds())
+
+			// Cache the newly fetched data
+			now := time.Now()
+			found := make(map[string]*WalletAuth, len(fetched))
+			unknown := make(map[string]*WalletAuth)
+			for _, result := range fetched {
+				switch {
+				case result.Err == nil:
+					result.Status.FetchedAt = now
+					found[result.WalletAddress] = result.Status
+				case errors.Is(result.Err, ErrWalletNotFound):
+					unknown[result.WalletAddress] = negativeEntry(result.WalletAddress)
+				}
+				results[result.WalletAddress] = result
+			}
+			_ = s.trustStorePort.BatchSet(lookupCtx, found, s.cachePolicy.storeTTL())
+			if s.cachePolicy.NegativeTTL > 0 {
+				_ = s.trustStorePort.BatchSet(lookupCtx, unknown, s.cachePolicy.NegativeTTL)
+			}
+
+			for addr, call := range owned {
+				result, ok := results[addr]
+				if !ok {
+					result = StatusResult{WalletAddress: addr, Err: ErrWalletNotFound}
+					results[addr] = result
+				}
+				s.lookups.finish(addr, call, result.Status, result.Err)
+			}
+		}
+	}
+
+	if len(joined) > 0 {
+		metrics.BlockchainRequestsCoalesced.WithLabelValues("batch_get_status").Add(float64(len(joined)))
+	}
+	for addr, call := range joined {
+		status, err := call.wait(ctx)
+		results[addr] = StatusResult{WalletAddress: addr, Status: status, Err: err}
+	}
+
+	return results
+}
@@ -0,0 +1,97 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// waitUntil polls cond until it holds or two seconds have passed
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestConcurrentLookupsShareOneQuery(t *testing.T) {
+	const callers = 20
+
+	qubic := newFakeQubic()
+	qubic.setStatus(testWallet, auth.StatusActive, 80)
+	svc := newTestService(t, qubic)
+	release := qubic.hold()
+
+	coalesced := testutil.ToFloat64(metrics.BlockchainRequestsCoalesced.WithLabelValues("get_status"))
+	joined := func() int {
+		return int(testutil.ToFloat64(metrics.BlockchainRequestsCoalesced.WithLabelValues("get_status")) - coalesced)
+	}
+
+	type result struct {
+		status *auth.WalletAuth
+		err    error
+	}
+	results := make([]result, callers)
+	var wg sync.WaitGroup
+	lookup := func(ctx context.Context, i int) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i].status, results[i].err = svc.GetStatus(ctx, testWallet)
+		}()
+	}
+
+	// The first caller starts the query and gives up while it is in flight
+	ownerCtx, cancelOwner := context.WithCancel(context.Background())
+	lookup(ownerCtx, 0)
+	waitUntil(t, "the first query", func() bool { return qubic.lookups() == 1 })
+
+	// So does one of the callers that joined it
+	joinerCtx, cancelJoiner := context.WithCancel(context.Background())
+	lookup(joinerCtx, 1)
+	for i := 2; i < callers; i++ {
+		lookup(context.Background(), i)
+	}
+	waitUntil(t, "every caller to join the query", func() bool { return joined() == callers-1 })
+
+	cancelOwner()
+	cancelJoiner()
+	time.Sleep(10 * time.Millisecond)
+	release()
+	wg.Wait()
+
+	if got := qubic.lookups(); got != 1 {
+		t.Errorf("%d callers made %d blockchain queries, want 1", callers, got)
+	}
+	if !errors.Is(results[1].err, context.Canceled) {
+		t.Errorf("cancelled joined caller: error = %v, want %v", results[1].err, context.Canceled)
+	}
+	for i, r := range results {
+		if i == 1 {
+			continue
+		}
+		if r.err != nil || r.status == nil || r.status.Status != auth.StatusActive {
+			t.Errorf("caller %d: GetStatus = %+v, %v, want the shared status", i, r.status, r.err)
+		}
+	}
+
+	// The shared result was cached
+	if _, err := svc.GetStatus(context.Background(), testWallet); err != nil {
+		t.Fatalf("GetStatus after the shared query: %v", err)
+	}
+	if got := qubic.lookups(); got != 1 {
+		t.Errorf("blockchain queries after a cached lookup = %d, want 1", got)
+	}
+}
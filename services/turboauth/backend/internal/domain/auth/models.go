@@ -16,6 +16,9 @@ const (
 )
 
 // WalletAuth represents the complete authentication state
+// FetchedAt is when the status was read from the blockchain; Stale is set when
+// it is served from cache past its TTL, before a refresh from the blockchain
+// has succeeded.
 type WalletAuth struct {
 	WalletAddress   string     `json:"wallet_address"`
 	Status          AuthStatus `json:"status"`
@@ -23,32 +26,90 @@ type WalletAuth struct {
 	ContractAddress string     `json:"contract_address"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 	CreatedAt       time.Time  `json:"created_at"`
+	FetchedAt       time.Time  `json:"fetched_at"`
+	Stale           bool       `json:"stale,omitempty"`
+}
+
+// MaxBatchSize is the most wallet addresses a batch lookup accepts
+const MaxBatchSize = 1000
+
+// StatusResult is the outcome of looking up one wallet of a batch
+// Exactly one of Status and Err is set; Err is ErrWalletNotFound for wallets
+// the contract does not know.
+type StatusResult struct {
+	WalletAddress string
+	Status        *WalletAuth
+	Err           error
 }
 
 // VerifyRequest represents a wallet verification request
+// Domain optionally names the relying party; when set, the signed message
+// must have been issued for that domain.
 type VerifyRequest struct {
 	WalletAddress string `json:"wallet_address" validate:"required"`
 	Signature     string `json:"signature" validate:"required"`
 	Message       string `json:"message" validate:"required"`
+	Domain        string `json:"domain,omitempty"`
 }
 
 // SetStatusRequest represents a request to update wallet status
+// AdminSignature is the admin identity's signature over SigningMessage();
+// Nonce and Timestamp (unix seconds) make each signed request single-use.
 type SetStatusRequest struct {
 	WalletAddress  string     `json:"wallet_address" validate:"required"`
 	Status         AuthStatus `json:"status" validate:"required,oneof=ACTIVE BLOCKED REVIEW"`
 	TrustScore     int        `json:"trust_score" validate:"min=0,max=100"`
+	AdminAddress   string     `json:"admin_address" validate:"required"`
+	Nonce          string     `json:"nonce" validate:"required"`
+	Timestamp      int64      `json:"timestamp" validate:"required"`
 	AdminSignature string     `json:"admin_signature" validate:"required"`
 }
 
+// TxState represents the confirmation state of a submitted transaction
+type TxState string
+
+const (
+	TxPending   TxState = "PENDING"
+	TxConfirmed TxState = "CONFIRMED"
+	TxFailed    TxState = "FAILED"
+)
+
+// TxResult is what the node reports about a submitted transaction
+type TxResult struct {
+	State TxState
+
+	// Reason explains why a transaction failed
+	Reason string
+}
+
+// StatusChange tracks a status-change transaction until it is included on-chain or expires
+type StatusChange struct {
+	TxHash        string     `json:"tx_hash"`
+	WalletAddress string     `json:"wallet_address"`
+	Status        AuthStatus `json:"status"`
+	TrustScore    int        `json:"trust_score"`
+	State         TxState    `json:"state"`
+	Error         string     `json:"error,omitempty"`
+	SubmittedAt   time.Time  `json:"submitted_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
 // Common errors
 var (
 	ErrWalletNotFound    = errors.New("wallet not found")
 	ErrInvalidSignature  = errors.New("invalid signature")
+	ErrInvalidAddress    = errors.New("invalid wallet address")
 	ErrInvalidStatus     = errors.New("invalid status")
 	ErrUnauthorized      = errors.New("unauthorized")
 	ErrBlockchainFailure = errors.New("blockchain operation failed")
 	ErrCacheFailure      = errors.New("cache operation failed")
 	ErrInvalidTrustScore = errors.New("trust score must be between 0 and 100")
+	ErrTxNotFound        = errors.New("transaction not found")
+	ErrProposalNotFound  = errors.New("proposal not found")
+	ErrProposalClosed    = errors.New("proposal is no longer pending")
+	ErrAlreadyApproved   = errors.New("proposal already approved by this admin")
+	ErrWarmupRunning     = errors.New("cache warm-up already running")
+	ErrBatchTooLarge     = errors.New("too many wallets in batch")
 )
 
 // IsValid checks if the trust score is valid
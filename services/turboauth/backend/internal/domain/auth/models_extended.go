@@ -6,10 +6,14 @@ import (
 )
 
 // Session represents an authenticated session after wallet verification
+// Listed sessions carry a Handle instead of their ID.
 type Session struct {
-	SessionID     string    `json:"session_id"`
+	SessionID     string    `json:"session_id,omitempty"`
+	Handle        string    `json:"handle,omitempty"`  // Names a listed session, see sessionHandle
+	Current       bool      `json:"current,omitempty"` // Set on the listed session of the caller's token
 	WalletAddress string    `json:"wallet_address"`
-	Token         string    `json:"token"` // JWT token
+	Token         string    `json:"token,omitempty"`         // JWT token, only returned when issued
+	RefreshToken  string    `json:"refresh_token,omitempty"` // Single-use, only returned when issued
 	ExpiresAt     time.Time `json:"expires_at"`
 	CreatedAt     time.Time `json:"created_at"`
 	LastActivity  time.Time `json:"last_activity"`
@@ -22,7 +26,36 @@ type SessionRequest struct {
 	WalletAddress string `json:"wallet_address" validate:"required"`
 	Signature     string `json:"signature" validate:"required"`
 	Message       string `json:"message" validate:"required"`
-	TTL           int    `json:"ttl,omitempty"` // Session TTL in seconds (default: 3600)
+	Domain        string `json:"domain,omitempty"`
+	TTL           int    `json:"ttl,omitempty"` // Session TTL in seconds, bounded by the session policy
+
+	// Client details recorded on the session, taken from the connection
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// SessionPolicy configures session lifetimes
+type SessionPolicy struct {
+	// DefaultTTL is the lifetime of sessions that do not request one
+	DefaultTTL time.Duration
+
+	// MaxTTL caps requested lifetimes; revocations of whole sessions and
+	// wallets are kept this long, since no token can outlive it
+	MaxTTL time.Duration
+}
+
+// ttl returns the lifetime of a session requesting ttlSeconds
+func (p SessionPolicy) ttl(ttlSeconds int) time.Duration {
+	ttl := p.DefaultTTL
+	if ttlSeconds > 0 {
+		ttl = time.Duration(ttlSeconds) * time.Second
+	}
+	return min(ttl, p.MaxTTL)
+}
+
+// RevokeTokenRequest asks for a token to be revoked by whoever holds it
+type RevokeTokenRequest struct {
+	Token string `json:"token" validate:"required"`
 }
 
 // SessionResponse represents the response after session creation
@@ -33,8 +66,63 @@ type SessionResponse struct {
 
 // RefreshSessionRequest represents a request to refresh a session
 type RefreshSessionRequest struct {
-	SessionID string `json:"session_id" validate:"required"`
-	Token     string `json:"token" validate:"required"`
+	RefreshToken string `json:"refresh_token" validate:"required"`
+
+	// Client details reported if the refresh token turns out to be reused
+	IPAddress string `json:"-"`
+	UserAgent string `json:"-"`
+}
+
+// RefreshFamily tracks the chain of refresh tokens of one session
+// Each refresh token can be used once and is replaced by the next one; only
+// the hash of the current one is stored. Presenting any other token of the
+// family means an earlier token was copied, so the family is revoked.
+type RefreshFamily struct {
+	FamilyID      string        `json:"family_id"`
+	SessionID     string        `json:"session_id"`
+	WalletAddress string        `json:"wallet_address"`
+	TokenHash     string        `json:"token_hash"` // hash of the current token
+	Generation    int           `json:"generation"` // number of rotations so far
+	Lifetime      time.Duration `json:"lifetime"`   // how long each token stays usable
+	ExpiresAt     time.Time     `json:"expires_at"`
+	Revoked       bool          `json:"revoked"`
+}
+
+// ActiveSessionsRequest asks for the active sessions of a wallet
+// AccessToken must be a session token of the same wallet, which proves the
+// caller signed in as it.
+type ActiveSessionsRequest struct {
+	WalletAddress string `json:"wallet_address" validate:"required"`
+	AccessToken   string `json:"-"`
+}
+
+// DeleteSessionRequest asks to end a session of the caller's wallet
+// Handle names a session listed by GetActiveSessions.
+type DeleteSessionRequest struct {
+	AccessToken string `json:"-"`
+	Handle      string `json:"handle" validate:"required"`
+}
+
+// TokenClaims are the claims carried by a session token
+type TokenClaims struct {
+	TokenID       string
+	WalletAddress string
+	SessionID     string
+	Status        AuthStatus // status when the token was issued
+	TrustScore    int
+	IssuedAt      time.Time
+	ExpiresAt     time.Time
+}
+
+// JSONWebKey is a public token verification key (RFC 7517)
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Curve     string `json:"crv"`
+	X         string `json:"x"`
+	Y         string `json:"y,omitempty"`
+	KeyID     string `json:"kid"`
+	Algorithm string `json:"alg"`
+	Use       string `json:"use"`
 }
 
 // RateLimitInfo represents rate limiting information for a wallet
@@ -83,11 +171,36 @@ type VerifyResult struct {
 	Error         string      `json:"error,omitempty"`
 }
 
+// Challenge represents a server-issued, single-use message a wallet signs to prove ownership
+type Challenge struct {
+	Nonce         string    `json:"nonce"`
+	WalletAddress string    `json:"wallet_address"`
+	Domain        string    `json:"domain"`
+	Scopes        []string  `json:"scopes,omitempty"`
+	Message       string    `json:"message"` // Rendered SignInMessage
+	IssuedAt      time.Time `json:"issued_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// ChallengeRequest represents a request for a new sign-in challenge
+type ChallengeRequest struct {
+	WalletAddress string   `json:"wallet_address" validate:"required"`
+	Domain        string   `json:"domain" validate:"required"`
+	URI           string   `json:"uri" validate:"required"`
+	Scopes        []string `json:"scopes,omitempty"`
+}
+
 // Additional errors
 var (
-	ErrSessionExpired    = errors.New("session expired")
-	ErrSessionNotFound   = errors.New("session not found")
-	ErrInvalidToken      = errors.New("invalid token")
-	ErrRateLimitExceeded = errors.New("rate limit exceeded")
-	ErrWebhookFailed     = errors.New("webhook delivery failed")
+	ErrSessionExpired     = errors.New("session expired")
+	ErrSessionNotFound    = errors.New("session not found")
+	ErrInvalidToken       = errors.New("invalid token")
+	ErrRefreshTokenReused = errors.New("refresh token already used, session revoked")
+	ErrRateLimitExceeded  = errors.New("rate limit exceeded")
+	ErrWebhookFailed      = errors.New("webhook delivery failed")
+	ErrChallengeNotFound  = errors.New("challenge not found or already used")
+	ErrChallengeExpired   = errors.New("challenge expired")
+	ErrInvalidMessage     = errors.New("invalid sign-in message")
+	ErrDomainNotAllowed   = errors.New("domain not allowed")
+	ErrWalletBlocked      = errors.New("wallet blocked")
 )
@@ -0,0 +1,83 @@
+package auth
+
+// Option wires an optional port or a policy into the service
+// Features whose port is not set are disabled.
+type Option func(*Service)
+
+// WithCachePolicy overrides how long wallet statuses are cached
+func WithCachePolicy(policy CachePolicy) Option {
+	return func(s *Service) {
+		s.cachePolicy = policy
+	}
+}
+
+// WithSignInPolicy overrides the sign-in challenge settings
+func WithSignInPolicy(policy SignInPolicy) Option {
+	return func(s *Service) {
+		s.signInPolicy = policy
+	}
+}
+
+// WithAdminPolicy sets who may change wallet statuses; without it no admin
+// is registered and every status change is rejected
+func WithAdminPolicy(policy AdminPolicy) Option {
+	return func(s *Service) {
+		s.adminPolicy = policy
+	}
+}
+
+// WithAccessStats records wallet lookups for the access-statistics warm-up source
+func WithAccessStats(accessStats AccessStatsPort) Option {
+	return func(s *Service) {
+		s.accessStats = accessStats
+	}
+}
+
+// WithSessionPort stores the sessions created after wallet verification
+func WithSessionPort(sessionPort SessionPort) Option {
+	return func(s *Service) {
+		s.sessionPort = sessionPort
+	}
+}
+
+// WithRateLimitPort limits how often a wallet can create sessions
+func WithRateLimitPort(rateLimitPort RateLimitPort) Option {
+	return func(s *Service) {
+		s.rateLimitPort = rateLimitPort
+	}
+}
+
+// WithWebhookPort notifies webhook subscribers of session events
+func WithWebhookPort(webhookPort WebhookPort) Option {
+	return func(s *Service) {
+		s.webhookPort = webhookPort
+	}
+}
+
+// WithTokenPort issues and validates session tokens
+func WithTokenPort(tokenPort TokenPort) Option {
+	return func(s *Service) {
+		s.tokenPort = tokenPort
+	}
+}
+
+// WithSessionPolicy overrides the default session lifetimes
+func WithSessionPolicy(policy SessionPolicy) Option {
+	return func(s *Service) {
+		s.sessionPolicy = policy
+	}
+}
+
+// WithRevocationPort revokes session tokens on logout and when a wallet is blocked
+func WithRevocationPort(revocationPort RevocationPort) Option {
+	return func(s *Service) {
+		s.revocationPort = revocationPort
+	}
+}
+
+// WithRefreshTokenPort issues rotating refresh tokens with sessions
+func WithRefreshTokenPort(refreshTokenPort RefreshTokenPort) Option {
+	return func(s *Service) {
+		s.refreshTokenPort = refreshTokenPort
+	}
+}
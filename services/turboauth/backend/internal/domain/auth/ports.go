@@ -13,8 +13,14 @@ type QubicPort interface {
 	// SetAuthStatus updates the authentication status on the smart contract
 	SetAuthStatus(ctx context.Context, req *SetStatusRequest) (txHash string, err error)
 
+	// GetTransactionState reports whether a submitted transaction has been
+	// included on-chain, and why it failed if it did
+	GetTransactionState(ctx context.Context, txHash string) (*TxResult, error)
+
 	// BatchGetAuthStatus retrieves multiple statuses in a single call (performance optimization)
-	BatchGetAuthStatus(ctx context.Context, walletAddresses []string) ([]*WalletAuth, error)
+	// It returns one result per wallet, in the order given; an error means
+	// the batch as a whole failed
+	BatchGetAuthStatus(ctx context.Context, walletAddresses []string) ([]StatusResult, error)
 
 	// GetContractAddress returns the current smart contract address
 	GetContractAddress() string
@@ -28,9 +34,6 @@ type WalletVerifierPort interface {
 	// VerifySignature verifies that the signature was created by the wallet owner
 	VerifySignature(ctx context.Context, walletAddress, message, signature string) (bool, error)
 
-	// GenerateChallenge generates a challenge message for wallet verification
-	GenerateChallenge(walletAddress string) string
-
 	// ValidateAddress checks if a wallet address is valid
 	ValidateAddress(walletAddress string) bool
 }
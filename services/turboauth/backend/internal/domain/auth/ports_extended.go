@@ -10,22 +10,108 @@ type SessionPort interface {
 	// CreateSession creates a new authenticated session
 	CreateSession(ctx context.Context, session *Session) error
 
-	// GetSession retrieves a session by ID
+	// GetSession retrieves a session by ID; it returns ErrSessionNotFound if
+	// it does not exist or has been removed, and ErrSessionExpired if it has
+	// expired but not been removed yet
 	GetSession(ctx context.Context, sessionID string) (*Session, error)
 
-	// RefreshSession extends the session expiration
+	// RefreshSession extends the session expiration by its original lifetime,
+	// counted from now, and records the activity
 	RefreshSession(ctx context.Context, sessionID string) (*Session, error)
 
-	// DeleteSession invalidates a session
+	// DeleteSession invalidates a session; it returns ErrSessionNotFound if
+	// it does not exist
 	DeleteSession(ctx context.Context, sessionID string) error
 
-	// GetActiveSessions returns all active sessions for a wallet
+	// GetActiveSessions returns all unexpired sessions for a wallet, oldest first
 	GetActiveSessions(ctx context.Context, walletAddress string) ([]*Session, error)
 
-	// CleanupExpiredSessions removes expired sessions
+	// CleanupExpiredSessions removes expired sessions and returns how many
+	// were removed
 	CleanupExpiredSessions(ctx context.Context) (int, error)
 }
 
+// RefreshTokenPort defines the interface for persisting refresh-token families
+type RefreshTokenPort interface {
+	// CreateFamily stores a new refresh-token family until it expires
+	CreateFamily(ctx context.Context, family *RefreshFamily) error
+
+	// GetFamily retrieves a family by ID; it returns ErrInvalidToken if the
+	// family is unknown or has expired
+	GetFamily(ctx context.Context, familyID string) (*RefreshFamily, error)
+
+	// UpdateFamily atomically applies update to a stored family and returns
+	// the result; it returns ErrInvalidToken if the family is unknown or has
+	// expired, and if update returns an error nothing is stored
+	UpdateFamily(ctx context.Context, familyID string, update func(*RefreshFamily) error) (*RefreshFamily, error)
+}
+
+// ProposalPort defines the interface for persisting status-change proposals
+type ProposalPort interface {
+	// CreateProposal stores a new proposal
+	CreateProposal(ctx context.Context, proposal *Proposal) error
+
+	// GetProposal retrieves a proposal by ID; it returns ErrProposalNotFound
+	// if it does not exist
+	GetProposal(ctx context.Context, proposalID string) (*Proposal, error)
+
+	// UpdateProposal atomically applies update to a stored proposal and
+	// returns the result; if update returns an error nothing is stored
+	UpdateProposal(ctx context.Context, proposalID string, update func(*Proposal) error) (*Proposal, error)
+}
+
+// StatusChangePort defines the interface for persisting tracked status-change
+// transactions, so every instance can report and follow them
+type StatusChangePort interface {
+	// CreateStatusChange stores a newly submitted status change
+	CreateStatusChange(ctx context.Context, change *StatusChange) error
+
+	// GetStatusChange retrieves a status change by transaction hash; it
+	// returns ErrTxNotFound if it does not exist
+	GetStatusChange(ctx context.Context, txHash string) (*StatusChange, error)
+
+	// PendingStatusChanges returns the status changes still awaiting confirmation
+	PendingStatusChanges(ctx context.Context) ([]*StatusChange, error)
+
+	// UpdateStatusChange atomically applies update to a stored status change
+	// and returns the result; if update returns an error nothing is stored
+	UpdateStatusChange(ctx context.Context, txHash string, update func(*StatusChange) error) (*StatusChange, error)
+}
+
+// NoncePort defines the interface for storing issued challenges
+// Challenges are keyed by the exact message issued, so a signed message can
+// only be redeemed once
+type NoncePort interface {
+	// StoreChallenge records an issued challenge until it expires
+	StoreChallenge(ctx context.Context, challenge *Challenge) error
+
+	// ConsumeChallenge atomically removes and returns the challenge issued for
+	// message; it returns ErrChallengeNotFound if it is unknown, expired or
+	// already consumed
+	ConsumeChallenge(ctx context.Context, message string) (*Challenge, error)
+
+	// ClaimNonce records a single-use nonce for ttl; it returns false if the
+	// nonce has already been claimed
+	ClaimNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+}
+
+// WarmupSourcePort lists wallets worth prefetching into the trust store
+type WarmupSourcePort interface {
+	// HotWallets returns up to limit wallet addresses, most valuable first;
+	// a limit of zero returns all of them
+	HotWallets(ctx context.Context, limit int) ([]string, error)
+}
+
+// AccessStatsPort records which wallets are looked up, so the most requested
+// ones can be prefetched after a restart
+type AccessStatsPort interface {
+	// RecordAccess counts a lookup of each wallet; it must not block
+	RecordAccess(walletAddresses ...string)
+
+	// HotWallets returns the most looked-up wallets, most requested first
+	WarmupSourcePort
+}
+
 // RateLimitPort defines the interface for rate limiting
 type RateLimitPort interface {
 	// CheckRateLimit checks if a wallet has exceeded rate limits
@@ -59,14 +145,45 @@ type WebhookPort interface {
 	RetryFailedWebhooks(ctx context.Context) error
 }
 
-// TokenPort defines the interface for JWT token operations
-type TokenPort interface {
-	// GenerateToken creates a new JWT token
-	GenerateToken(walletAddress string, expiresAt time.Time) (string, error)
+// RevocationPort records revoked tokens until they would have expired anyway
+type RevocationPort interface {
+	// RevokeToken revokes a single token until expiresAt
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// RevokeSession revokes every token of a session until expiresAt
+	RevokeSession(ctx context.Context, sessionID string, expiresAt time.Time) error
 
-	// ValidateToken validates a JWT token
-	ValidateToken(token string) (walletAddress string, err error)
+	// RevokeWallet revokes every token issued for a wallet up to now, until
+	// expiresAt; tokens issued later are not affected
+	RevokeWallet(ctx context.Context, walletAddress string, expiresAt time.Time) error
 
-	// RefreshToken creates a new token from an existing one
-	RefreshToken(token string) (string, error)
+	// IsRevoked reports whether a token has been revoked by ID, session or wallet
+	IsRevoked(ctx context.Context, claims *TokenClaims) (bool, error)
+
+	// BlockWallet marks a wallet blocked from now until expiresAt, or until it
+	// is unblocked; the chain only reports the block once it is confirmed
+	BlockWallet(ctx context.Context, walletAddress string, expiresAt time.Time) error
+
+	// UnblockWallet removes the blocked marker of a wallet
+	UnblockWallet(ctx context.Context, walletAddress string) error
+
+	// BlockedSince returns when a wallet was marked blocked, or the zero time
+	// if it is not
+	BlockedSince(ctx context.Context, walletAddress string) (time.Time, error)
+}
+
+// TokenPort defines the interface for JWT token operations
+type TokenPort interface {
+	// GenerateToken signs a new token carrying claims; the token ID and issue
+	// time are set by the port
+	GenerateToken(claims *TokenClaims) (string, error)
+
+	// ValidateToken verifies a token's signature and lifetime, checks it has
+	// not been revoked and returns its claims; it returns ErrInvalidToken if
+	// the token cannot be trusted
+	ValidateToken(ctx context.Context, token string) (*TokenClaims, error)
+
+	// PublicKeys returns the keys tokens can be verified with, including
+	// retired keys whose tokens may still be in use
+	PublicKeys() []JSONWebKey
 }
@@ -0,0 +1,254 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ProposalState represents the lifecycle state of a status-change proposal
+type ProposalState string
+
+const (
+	ProposalPending   ProposalState = "PENDING"   // Collecting approvals
+	ProposalApproved  ProposalState = "APPROVED"  // Threshold reached, being submitted
+	ProposalSubmitted ProposalState = "SUBMITTED" // Transaction broadcast
+	ProposalFailed    ProposalState = "FAILED"    // Submission failed
+	ProposalExpired   ProposalState = "EXPIRED"   // Not approved in time
+)
+
+// Approval is an admin's signed approval of a proposal
+type Approval struct {
+	AdminAddress string    `json:"admin_address"`
+	Signature    string    `json:"signature"`
+	ApprovedAt   time.Time `json:"approved_at"`
+}
+
+// Proposal is a status change that needs approval from several admins
+// before it is submitted on-chain
+type Proposal struct {
+	ProposalID    string        `json:"proposal_id"`
+	WalletAddress string        `json:"wallet_address"`
+	Status        AuthStatus    `json:"status"`
+	TrustScore    int           `json:"trust_score"`
+	ProposedBy    string        `json:"proposed_by"`
+	Approvals     []Approval    `json:"approvals"`
+	Threshold     int           `json:"threshold"`
+	State         ProposalState `json:"state"`
+	TxHash        string        `json:"tx_hash,omitempty"`
+	Error         string        `json:"error,omitempty"`
+	CreatedAt     time.Time     `json:"created_at"`
+	ExpiresAt     time.Time     `json:"expires_at"`
+	UpdatedAt     time.Time     `json:"updated_at"`
+}
+
+// ApproveRequest represents an admin's approval of a pending proposal
+// AdminSignature is the admin identity's signature over SigningMessage().
+type ApproveRequest struct {
+	ProposalID     string `json:"proposal_id"`
+	AdminAddress   string `json:"admin_address" validate:"required"`
+	Nonce          string `json:"nonce" validate:"required"`
+	Timestamp      int64  `json:"timestamp" validate:"required"`
+	AdminSignature string `json:"admin_signature" validate:"required"`
+}
+
+// SigningMessage returns the canonical text an admin signs to approve the
+// proposal; it repeats the proposed change so admins sign what they approve:
+//
+//	TurboAuth ApproveStatus
+//	Proposal: 9b2c4e1f0a7d3b5c8e6f1a2b3c4d5e6f
+//	Wallet: BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK
+//	Status: BLOCKED
+//	Trust Score: 0
+//	Nonce: 7d1f0c2e
+//	Timestamp: 1735732800
+func (r *ApproveRequest) SigningMessage(proposal *Proposal) string {
+	return fmt.Sprintf("TurboAuth ApproveStatus\nProposal: %s\nWallet: %s\nStatus: %s\nTrust Score: %d\nNonce: %s\nTimestamp: %d",
+		proposal.ProposalID, proposal.WalletAddress, proposal.Status, proposal.TrustScore, r.Nonce, r.Timestamp)
+}
+
+// hasApproval reports whether admin has already approved the proposal
+func (p *Proposal) hasApproval(admin string) bool {
+	for _, approval := range p.Approvals {
+		if approval.AdminAddress == admin {
+			return true
+		}
+	}
+	return false
+}
+
+// checkPending returns an error unless the proposal can still be approved
+func (p *Proposal) checkPending(now time.Time) error {
+	if p.State != ProposalPending {
+		return fmt.Errorf("%w: proposal is %s", ErrProposalClosed, p.State)
+	}
+	if now.After(p.ExpiresAt) {
+		return fmt.Errorf("%w: proposal expired", ErrProposalClosed)
+	}
+	return nil
+}
+
+// ProposeStatus creates a proposal for a status change, signed by the
+// proposing admin over ProposalMessage(), which counts as its first approval
+func (s *Service) ProposeStatus(ctx context.Context, req *SetStatusRequest) (*Proposal, error) {
+	if !s.walletPort.ValidateAddress(req.WalletAddress) {
+		return nil, ErrInvalidAddress
+	}
+	// Checked before authorization, which spends the request's nonce
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	if err := s.authorizeAdminSignature(ctx, req.AdminAddress, req.Nonce, req.Timestamp, req.AdminSignature, req.ProposalMessage()); err != nil {
+		return nil, err
+	}
+
+	proposalID, err := generateNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	proposal := &Proposal{
+		ProposalID:    proposalID,
+		WalletAddress: req.WalletAddress,
+		Status:        req.Status,
+		TrustScore:    req.TrustScore,
+		ProposedBy:    req.AdminAddress,
+		Approvals: []Approval{{
+			AdminAddress: req.AdminAddress,
+			Signature:    req.AdminSignature,
+			ApprovedAt:   now,
+		}},
+		Threshold: s.adminPolicy.threshold(),
+		State:     ProposalPending,
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.adminPolicy.ProposalTTL),
+		UpdatedAt: now,
+	}
+
+	if len(proposal.Approvals) >= proposal.Threshold {
+		proposal.State = ProposalApproved
+	}
+
+	if err := s.proposalPort.CreateProposal(ctx, proposal); err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("proposal_id", proposalID).
+		Str("wallet", req.WalletAddress).
+		Str("status", string(req.Status)).
+		Str("admin", req.AdminAddress).
+		Msg("Status change proposed")
+
+	if proposal.State == ProposalApproved {
+		return s.executeProposal(ctx, proposal)
+	}
+	return proposal, nil
+}
+
+// ApproveProposal adds an admin's approval to a pending proposal and submits
+// the status change once the approval threshold is reached
+func (s *Service) ApproveProposal(ctx context.Context, req *ApproveRequest) (*Proposal, error) {
+	proposal, err := s.proposalPort.GetProposal(ctx, req.ProposalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := proposal.checkPending(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorizeAdminSignature(ctx, req.AdminAddress, req.Nonce, req.Timestamp, req.AdminSignature, req.SigningMessage(proposal)); err != nil {
+		return nil, err
+	}
+
+	// Only the approval that reaches the threshold moves the proposal to
+	// APPROVED, so the change is submitted exactly once
+	proposal, err = s.proposalPort.UpdateProposal(ctx, req.ProposalID, func(p *Proposal) error {
+		now := time.Now()
+		if err := p.checkPending(now); err != nil {
+			return err
+		}
+		if p.hasApproval(req.AdminAddress) {
+			return ErrAlreadyApproved
+		}
+
+		p.Approvals = append(p.Approvals, Approval{
+			AdminAddress: req.AdminAddress,
+			Signature:    req.AdminSignature,
+			ApprovedAt:   now,
+		})
+		if len(p.Approvals) >= p.Threshold {
+			p.State = ProposalApproved
+		}
+		p.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().
+		Str("proposal_id", proposal.ProposalID).
+		Str("admin", req.AdminAddress).
+		Int("approvals", len(proposal.Approvals)).
+		Int("threshold", proposal.Threshold).
+		Msg("Proposal approved")
+
+	if proposal.State == ProposalApproved {
+		return s.executeProposal(ctx, proposal)
+	}
+	return proposal, nil
+}
+
+// GetProposal returns a proposal and its approvals
+func (s *Service) GetProposal(ctx context.Context, proposalID string) (*Proposal, error) {
+	proposal, err := s.proposalPort.GetProposal(ctx, proposalID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Expiry is not written back, report it on read
+	if proposal.State == ProposalPending && time.Now().After(proposal.ExpiresAt) {
+		proposal.State = ProposalExpired
+	}
+	return proposal, nil
+}
+
+// executeProposal submits an approved proposal on-chain and records the outcome
+func (s *Service) executeProposal(ctx context.Context, proposal *Proposal) (*Proposal, error) {
+	txHash, submitErr := s.submitStatus(ctx, &SetStatusRequest{
+		WalletAddress: proposal.WalletAddress,
+		Status:        proposal.Status,
+		TrustScore:    proposal.TrustScore,
+		AdminAddress:  proposal.ProposedBy,
+	})
+
+	updated, err := s.proposalPort.UpdateProposal(ctx, proposal.ProposalID, func(p *Proposal) error {
+		if submitErr != nil {
+			p.State = ProposalFailed
+			p.Error = submitErr.Error()
+		} else {
+			p.State = ProposalSubmitted
+			p.TxHash = txHash
+		}
+		p.UpdatedAt = time.Now()
+		return nil
+	})
+	if err != nil {
+		log.Error().Err(err).Str("proposal_id", proposal.ProposalID).Msg("Failed to record proposal outcome")
+	}
+
+	if submitErr != nil {
+		return nil, submitErr
+	}
+	if err != nil {
+		// The change was submitted, report it even if the record is stale
+		proposal.State = ProposalSubmitted
+		proposal.TxHash = txHash
+		return proposal, nil
+	}
+	return updated, nil
+}
@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// EventRefreshTokenReused is the webhook event sent when a rotated refresh
+// token is presented again and its session is revoked
+const EventRefreshTokenReused = "refresh_token_reused"
+
+// issueRefreshToken starts the refresh-token family of a new session
+// Refresh tokens are "<family ID>.<secret>"; only a hash of the secret is stored.
+func (s *Service) issueRefreshToken(ctx context.Context, session *Session, lifetime time.Duration) (string, error) {
+	familyID, err := randomHex(16)
+	if err != nil {
+		return "", err
+	}
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.refreshTokenPort.CreateFamily(ctx, &RefreshFamily{
+		FamilyID:      familyID,
+		SessionID:     session.SessionID,
+		WalletAddress: session.WalletAddress,
+		TokenHash:     hashSecret(secret),
+		Lifetime:      lifetime,
+		ExpiresAt:     session.ExpiresAt,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return familyID + "." + secret, nil
+}
+
+// splitRefreshToken returns the family ID and secret of a refresh token
+func splitRefreshToken(refreshToken string) (familyID, secret string, err error) {
+	familyID, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || familyID == "" || secret == "" {
+		return "", "", ErrInvalidToken
+	}
+	return familyID, secret, nil
+}
+
+// rotateRefreshToken redeems the secret of a refresh token family and returns
+// the family with the token replacing it, expiring no later than the session
+// A token that is not the family's current one has been used before, which
+// only happens if it was copied: the family is revoked and the session ended,
+// so neither the legitimate client nor the copy can continue.
+func (s *Service) rotateRefreshToken(ctx context.Context, familyID, secret string, sessionExpiresAt time.Time, req *RefreshSessionRequest) (*RefreshFamily, string, error) {
+	next, err := randomHex(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var reused bool
+	family, err := s.refreshTokenPort.UpdateFamily(ctx, familyID, func(family *RefreshFamily) error {
+		reused = false
+
+		now := time.Now()
+		if family.Revoked || now.After(family.ExpiresAt) {
+			return ErrInvalidToken
+		}
+
+		if subtle.ConstantTimeCompare([]byte(family.TokenHash), []byte(hashSecret(secret))) != 1 {
+			reused = true
+			family.Revoked = true
+			return nil
+		}
+
+		family.TokenHash = hashSecret(next)
+		family.Generation++
+		family.ExpiresAt = now.Add(family.Lifetime)
+		if family.ExpiresAt.After(sessionExpiresAt) {
+			family.ExpiresAt = sessionExpiresAt
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if reused {
+		s.revokeReusedFamily(ctx, family, req)
+		return nil, "", ErrRefreshTokenReused
+	}
+
+	return family, familyID + "." + next, nil
+}
+
+// revokeReusedFamily ends the session of a family whose refresh token was
+// reused and reports it to webhook subscribers
+func (s *Service) revokeReusedFamily(ctx context.Context, family *RefreshFamily, req *RefreshSessionRequest) {
+	log.Warn().
+		Str("wallet", family.WalletAddress).
+		Str("session_id", family.SessionID).
+		Str("family_id", family.FamilyID).
+		Int("generation", family.Generation).
+		Str("ip", req.IPAddress).
+		Msg("Refresh token reused, revoking session")
+
+	if s.revocationPort != nil {
+		if err := s.revocationPort.RevokeSession(ctx, family.SessionID, time.Now().Add(s.revocationTTL())); err != nil {
+			log.Error().Err(err).Str("session_id", family.SessionID).Msg("Failed to revoke tokens of reused refresh token family")
+		}
+	}
+	if s.sessionPort != nil {
+		err := s.sessionPort.DeleteSession(ctx, family.SessionID)
+		if err != nil && !errors.Is(err, ErrSessionNotFound) {
+			log.Error().Err(err).Str("session_id", family.SessionID).Msg("Failed to end session of reused refresh token family")
+		}
+	}
+
+	if s.webhookPort != nil {
+		event := &WebhookEvent{
+			EventID:       generateEventID(),
+			EventType:     EventRefreshTokenReused,
+			WalletAddress: family.WalletAddress,
+			Timestamp:     time.Now(),
+			Data: map[string]interface{}{
+				"session_id": family.SessionID,
+				"family_id":  family.FamilyID,
+				"generation": family.Generation,
+				"ip_address": req.IPAddress,
+				"user_agent": req.UserAgent,
+			},
+		}
+		go s.webhookPort.SendWebhook(context.Background(), event)
+	}
+}
+
+// hashSecret returns the stored form of a refresh token secret
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(size int) (string, error) {
+	bytes := make([]byte, size)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
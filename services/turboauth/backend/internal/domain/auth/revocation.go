@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// revocationMargin keeps revocations a little past the expiry of the tokens
+// they cover, since validation tolerates some clock skew
+const revocationMargin = time.Minute
+
+// RevokeToken revokes a single token, e.g. when its holder logs out
+// Holding the token is what entitles the caller to revoke it.
+func (s *Service) RevokeToken(ctx context.Context, req *RevokeTokenRequest) error {
+	if s.tokenPort == nil || s.revocationPort == nil {
+		return ErrInvalidToken
+	}
+
+	claims, err := s.tokenPort.ValidateToken(ctx, req.Token)
+	if err != nil {
+		return err
+	}
+
+	if err := s.revocationPort.RevokeToken(ctx, claims.TokenID, claims.ExpiresAt.Add(revocationMargin)); err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("wallet", claims.WalletAddress).
+		Str("session_id", claims.SessionID).
+		Str("token_id", claims.TokenID).
+		Msg("Token revoked")
+	return nil
+}
+
+// EndAllSessions logs a wallet out everywhere: it revokes every token issued
+// for the wallet and ends its sessions, returning how many were ended
+// AccessToken must be a token of the wallet, as for listing its sessions.
+func (s *Service) EndAllSessions(ctx context.Context, req *ActiveSessionsRequest) (int, error) {
+	if s.sessionPort == nil {
+		return 0, ErrSessionNotFound
+	}
+
+	if _, err := s.authenticateWallet(ctx, req.AccessToken, req.WalletAddress); err != nil {
+		return 0, err
+	}
+
+	ended, err := s.endWalletSessions(ctx, req.WalletAddress)
+	if err != nil {
+		return 0, err
+	}
+
+	log.Info().
+		Str("wallet", req.WalletAddress).
+		Int("sessions", ended).
+		Msg("Wallet logged out everywhere")
+	return ended, nil
+}
+
+// endWalletSessions revokes every token issued for a wallet so far and
+// deletes its active sessions
+// Tokens are revoked first, so a failure to delete a session leaves it
+// unusable rather than alive.
+func (s *Service) endWalletSessions(ctx context.Context, walletAddress string) (int, error) {
+	if s.revocationPort != nil {
+		if err := s.revocationPort.RevokeWallet(ctx, walletAddress, time.Now().Add(s.revocationTTL())); err != nil {
+			return 0, err
+		}
+	}
+	if s.sessionPort == nil {
+		return 0, nil
+	}
+
+	sessions, err := s.sessionPort.GetActiveSessions(ctx, walletAddress)
+	if err != nil {
+		return 0, err
+	}
+
+	ended := 0
+	for _, session := range sessions {
+		err := s.sessionPort.DeleteSession(ctx, session.SessionID)
+		if errors.Is(err, ErrSessionNotFound) {
+			continue // ended concurrently
+		}
+		if err != nil {
+			return ended, err
+		}
+		ended++
+	}
+
+	return ended, nil
+}
+
+// markBlocked records whether a submitted status change blocks a wallet, so
+// sign-in and refresh are refused before the chain reports the block
+// The marker is kept as long as revocations; a later change to another status
+// removes it, and the chain's status applies again.
+func (s *Service) markBlocked(ctx context.Context, walletAddress string, status AuthStatus) error {
+	if s.revocationPort == nil {
+		return nil
+	}
+	if status == StatusBlocked {
+		return s.revocationPort.BlockWallet(ctx, walletAddress, time.Now().Add(s.revocationTTL()))
+	}
+	return s.revocationPort.UnblockWallet(ctx, walletAddress)
+}
+
+// checkNotBlocked returns ErrWalletBlocked if a wallet is blocked on chain or
+// a block of it is awaiting confirmation
+func (s *Service) checkNotBlocked(ctx context.Context, walletAddress string, status *WalletAuth) error {
+	if status.Status == StatusBlocked {
+		return ErrWalletBlocked
+	}
+	if s.revocationPort == nil {
+		return nil
+	}
+
+	since, err := s.revocationPort.BlockedSince(ctx, walletAddress)
+	if err != nil {
+		return err
+	}
+	if !since.IsZero() {
+		return ErrWalletBlocked
+	}
+	return nil
+}
+
+// revocationTTL is how long revocations of sessions and wallets are kept:
+// no token of theirs can outlive it
+func (s *Service) revocationTTL() time.Duration {
+	return s.sessionPolicy.MaxTTL + revocationMargin
+}
@@ -2,6 +2,8 @@ package auth
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"turboauth/pkg/metrics"
@@ -14,132 +16,200 @@ type Service struct {
 	qubicPort      QubicPort
 	walletPort     WalletVerifierPort
 	trustStorePort TrustStorePort
-	cacheTTL       time.Duration
+	noncePort      NoncePort
+	proposalPort   ProposalPort
+	cachePolicy    CachePolicy
+	signInPolicy   SignInPolicy
+	adminPolicy    AdminPolicy
+	txTracker      *TxTracker
+	lookups        *lookupGroup
+	revalidating   sync.Map // wallets with a background refresh running
 
 	// Optional extended features (can be nil)
-	sessionPort   SessionPort
-	rateLimitPort RateLimitPort
-	webhookPort   WebhookPort
-	tokenPort     TokenPort
+	accessStats      AccessStatsPort
+	sessionPort      SessionPort
+	sessionPolicy    SessionPolicy
+	rateLimitPort    RateLimitPort
+	webhookPort      WebhookPort
+	tokenPort        TokenPort
+	revocationPort   RevocationPort
+	refreshTokenPort RefreshTokenPort
 }
 
 // NewService creates a new authentication service
+// Policies and optional ports such as the session store are passed as
+// options; policies that are not set keep the defaults below.
 func NewService(
 	qubicPort QubicPort,
 	walletPort WalletVerifierPort,
 	trustStorePort TrustStorePort,
-	cacheTTL time.Duration,
+	noncePort NoncePort,
+	proposalPort ProposalPort,
+	statusChangePort StatusChangePort,
+	opts ...Option,
 ) *Service {
-	return &Service{
+	s := &Service{
 		qubicPort:      qubicPort,
 		walletPort:     walletPort,
 		trustStorePort: trustStorePort,
-		cacheTTL:       cacheTTL,
+		noncePort:      noncePort,
+		proposalPort:   proposalPort,
+		cachePolicy: CachePolicy{
+			TTL:         5 * time.Minute,
+			NegativeTTL: 30 * time.Second,
+		},
+		signInPolicy: SignInPolicy{
+			ChainID:      "mainnet",
+			ChallengeTTL: 5 * time.Minute,
+		},
+		adminPolicy: AdminPolicy{
+			MaxRequestAge: 5 * time.Minute,
+			ProposalTTL:   24 * time.Hour,
+		},
+		txTracker: NewTxTracker(qubicPort, trustStorePort, statusChangePort),
+		lookups:   newLookupGroup(),
+		sessionPolicy: SessionPolicy{
+			DefaultTTL: time.Hour,
+			MaxTTL:     24 * time.Hour,
+		},
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // GetStatus retrieves authentication status with L1/L2/L3 caching strategy
-// L1: In-memory (future optimization)
+// L1: In-memory (<1ms)
 // L2: Redis cache (~5-10ms)
 // L3: Qubic blockchain (~100-500ms)
+// L1 and L2 are both behind the trust store, which records their hit rates.
 func (s *Service) GetStatus(ctx context.Context, walletAddress string) (*WalletAuth, error) {
 	start := time.Now()
 
 	// Validate wallet address
 	if !s.walletPort.ValidateAddress(walletAddress) {
-		return nil, ErrInvalidSignature
+		return nil, ErrInvalidAddress
 	}
+	s.recordAccess(walletAddress)
 
-	// L2: Try Redis cache first
+	// L1/L2: Try the cache first
 	cached, err := s.trustStorePort.Get(ctx, walletAddress)
 	if err == nil && cached != nil {
-		metrics.CacheHits.WithLabelValues("L2").Inc()
+		if isNegative(cached) {
+			metrics.CacheNegativeHits.Inc()
+			return nil, ErrWalletNotFound
+		}
 		log.Debug().
 			Str("wallet", walletAddress).
 			Dur("duration_ms", time.Since(start)).
-			Msg("Cache hit (L2)")
-		return cached, nil
+			Msg("Cache hit")
+		return s.serveCached(cached), nil
 	}
-	metrics.CacheMisses.WithLabelValues("L2").Inc()
 
-	// L3: Query blockchain
-	log.Debug().Str("wallet", walletAddress).Msg("Querying blockchain")
-	status, err := s.qubicPort.GetAuthStatus(ctx, walletAddress)
-	if err != nil {
-		metrics.BlockchainRequestsTotal.WithLabelValues("get_status", "error").Inc()
-		return nil, err
-	}
-	metrics.BlockchainRequestsTotal.WithLabelValues("get_status", "success").Inc()
-	metrics.BlockchainRequestDuration.WithLabelValues("get_status").Observe(time.Since(start).Seconds())
+	// L3: Query blockchain, once for all concurrent misses of this wallet
+	return s.lookupStatus(ctx, walletAddress)
+}
 
-	// Cache for next time
-	if err := s.trustStorePort.Set(ctx, walletAddress, status, s.cacheTTL); err != nil {
-		log.Warn().Err(err).Msg("Failed to cache status")
+// BatchGetStatus retrieves multiple statuses efficiently
+// It returns one result per distinct wallet, in the order the wallets are
+// first given, so wallets that could not be looked up (including invalid
+// addresses) are reported rather than left out. At most MaxBatchSize
+// addresses are accepted.
+func (s *Service) BatchGetStatus(ctx context.Context, walletAddresses []string) ([]StatusResult, error) {
+	if len(walletAddresses) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: %d wallets requested, at most %d allowed",
+			ErrBatchTooLarge, len(walletAddresses), MaxBatchSize)
 	}
 
-	return status, nil
-}
+	// Look up each wallet once, invalid addresses are reported without a lookup
+	wallets := make([]string, 0, len(walletAddresses))
+	valid := make([]string, 0, len(walletAddresses))
+	resolved := make(map[string]StatusResult, len(walletAddresses))
+	seen := make(map[string]struct{}, len(walletAddresses))
+	for _, addr := range walletAddresses {
+		if _, ok := seen[addr]; ok {
+			continue
+		}
+		seen[addr] = struct{}{}
+		wallets = append(wallets, addr)
+
+		if !s.walletPort.ValidateAddress(addr) {
+			resolved[addr] = StatusResult{WalletAddress: addr, Err: ErrInvalidAddress}
+			continue
+		}
+		valid = append(valid, addr)
+	}
+	s.recordAccess(valid...)
 
-// BatchGetStatus retrieves multiple statuses efficiently
-func (s *Service) BatchGetStatus(ctx context.Context, walletAddresses []string) ([]*WalletAuth, error) {
 	// Try cache first
-	cachedData, _ := s.trustStorePort.BatchGet(ctx, walletAddresses)
+	cachedData, _ := s.trustStorePort.BatchGet(ctx, valid)
 
 	// Determine which addresses need blockchain lookup
 	var missingAddresses []string
-	for _, addr := range walletAddresses {
-		if _, found := cachedData[addr]; !found {
+	for _, addr := range valid {
+		if cached, found := cachedData[addr]; found {
+			if isNegative(cached) {
+				metrics.CacheNegativeHits.Inc()
+				resolved[addr] = StatusResult{WalletAddress: addr, Err: ErrWalletNotFound}
+				continue
+			}
+			resolved[addr] = StatusResult{WalletAddress: addr, Status: s.serveCached(cached)}
+		} else {
 			missingAddresses = append(missingAddresses, addr)
 		}
 	}
 
 	// Fetch missing from blockchain
-	var blockchainData []*WalletAuth
 	if len(missingAddresses) > 0 {
-		var err error
-		blockchainData, err = s.qubicPort.BatchGetAuthStatus(ctx, missingAddresses)
-		if err != nil {
-			return nil, err
+		for addr, result := range s.lookupStatuses(ctx, missingAddresses) {
+			resolved[addr] = result
 		}
-
-		// Cache the newly fetched data
-		cacheMap := make(map[string]*WalletAuth)
-		for _, data := range blockchainData {
-			cacheMap[data.WalletAddress] = data
-		}
-		_ = s.trustStorePort.BatchSet(ctx, cacheMap, s.cacheTTL)
 	}
 
 	// Combine cached and fresh data
-	result := make([]*WalletAuth, 0, len(walletAddresses))
-	for _, addr := range walletAddresses {
-		if cached, ok := cachedData[addr]; ok {
-			result = append(result, cached)
-		} else {
-			// Find in blockchain data
-			for _, data := range blockchainData {
-				if data.WalletAddress == addr {
-					result = append(result, data)
-					break
-				}
-			}
-		}
+	results := make([]StatusResult, 0, len(wallets))
+	for _, addr := range wallets {
+		results = append(results, resolved[addr])
 	}
 
-	return result, nil
+	return results, nil
 }
 
 // SetStatus updates the authentication status (admin only)
+// Statuses that need several approvals must go through ProposeStatus instead.
 func (s *Service) SetStatus(ctx context.Context, req *SetStatusRequest) (string, error) {
-	start := time.Now()
-
 	// Validate request
 	if !s.walletPort.ValidateAddress(req.WalletAddress) {
-		return "", ErrInvalidSignature
+		return "", ErrInvalidAddress
 	}
 
-	// TODO: Verify admin signature
-	// For now, we'll assume the caller is authorized
+	// Checked before authorization, which spends the request's nonce
+	if err := req.validate(); err != nil {
+		return "", err
+	}
+
+	if s.adminPolicy.requiresApproval(req.Status) {
+		return "", fmt.Errorf("%w: %s needs %d admin approvals, submit a proposal",
+			ErrUnauthorized, req.Status, s.adminPolicy.threshold())
+	}
+
+	if err := s.authorizeAdmin(ctx, req); err != nil {
+		log.Warn().
+			Err(err).
+			Str("wallet", req.WalletAddress).
+			Str("admin", req.AdminAddress).
+			Msg("Rejected status update")
+		return "", err
+	}
+
+	return s.submitStatus(ctx, req)
+}
+
+// submitStatus sends an authorized status change to the blockchain and
+// tracks it until it is confirmed
+func (s *Service) submitStatus(ctx context.Context, req *SetStatusRequest) (string, error) {
+	start := time.Now()
 
 	// Update on blockchain
 	txHash, err := s.qubicPort.SetAuthStatus(ctx, req)
@@ -150,22 +220,112 @@ func (s *Service) SetStatus(ctx context.Context, req *SetStatusRequest) (string,
 	metrics.BlockchainRequestsTotal.WithLabelValues("set_status", "success").Inc()
 	metrics.BlockchainRequestDuration.WithLabelValues("set_status").Observe(time.Since(start).Seconds())
 
-	// Invalidate cache
+	// Invalidate cache now, and again once the transaction is confirmed, since
+	// lookups before inclusion still read (and re-cache) the old on-chain status
 	if err := s.trustStorePort.Delete(ctx, req.WalletAddress); err != nil {
 		log.Warn().Err(err).Msg("Failed to invalidate cache")
 	}
+	if _, err := s.txTracker.Track(ctx, txHash, req); err != nil {
+		log.Warn().Err(err).Str("tx_hash", txHash).Msg("Failed to track status change")
+	}
+
+	// Blocking takes effect on sign-in and sessions right away rather than
+	// once the transaction is confirmed
+	if err := s.markBlocked(ctx, req.WalletAddress, req.Status); err != nil {
+		log.Error().Err(err).Str("wallet", req.WalletAddress).Msg("Failed to update blocked marker")
+	}
+	if req.Status == StatusBlocked {
+		if _, err := s.endWalletSessions(ctx, req.WalletAddress); err != nil {
+			log.Error().Err(err).Str("wallet", req.WalletAddress).Msg("Failed to revoke sessions of blocked wallet")
+		}
+	}
 
 	log.Info().
 		Str("wallet", req.WalletAddress).
 		Str("status", string(req.Status)).
+		Str("admin", req.AdminAddress).
 		Str("tx_hash", txHash).
-		Msg("Status updated")
+		Msg("Status update submitted")
 
 	return txHash, nil
 }
 
+// GetTransaction reports the confirmation state of a submitted status change
+func (s *Service) GetTransaction(ctx context.Context, txHash string) (*StatusChange, error) {
+	return s.txTracker.Get(ctx, txHash)
+}
+
+// Stop ends the service's background work
+func (s *Service) Stop() {
+	s.txTracker.Stop()
+}
+
+// CreateChallenge issues a single-use sign-in message for a wallet to sign
+func (s *Service) CreateChallenge(ctx context.Context, req *ChallengeRequest) (*Challenge, error) {
+	if !s.walletPort.ValidateAddress(req.WalletAddress) {
+		return nil, ErrInvalidAddress
+	}
+	if !s.signInPolicy.allowsDomain(req.Domain) {
+		return nil, ErrDomainNotAllowed
+	}
+	if err := validateSignInRequest(req); err != nil {
+		return nil, err
+	}
+
+	nonce, err := generateNonce()
+	if err != nil {
+		return nil, err
+	}
+
+	// The message carries second precision, keep the challenge consistent with it
+	now := time.Now().UTC().Truncate(time.Second)
+	message := &SignInMessage{
+		Domain:        req.Domain,
+		WalletAddress: req.WalletAddress,
+		URI:           req.URI,
+		Version:       SignInMessageVersion,
+		ChainID:       s.signInPolicy.ChainID,
+		Nonce:         nonce,
+		IssuedAt:      now,
+		ExpiresAt:     now.Add(s.signInPolicy.ChallengeTTL),
+		Scopes:        req.Scopes,
+	}
+
+	challenge := &Challenge{
+		Nonce:         nonce,
+		WalletAddress: req.WalletAddress,
+		Domain:        req.Domain,
+		Scopes:        req.Scopes,
+		Message:       message.String(),
+		IssuedAt:      message.IssuedAt,
+		ExpiresAt:     message.ExpiresAt,
+	}
+
+	if err := s.noncePort.StoreChallenge(ctx, challenge); err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
 // VerifyWallet verifies a wallet signature and returns its auth status
+// The signed message must be a sign-in message issued by CreateChallenge for
+// the same wallet and an allowed domain; it is consumed on success so the
+// signature cannot be replayed.
 func (s *Service) VerifyWallet(ctx context.Context, req *VerifyRequest) (*WalletAuth, bool, error) {
+	if !s.walletPort.ValidateAddress(req.WalletAddress) {
+		return nil, false, ErrInvalidAddress
+	}
+
+	// Check the message before spending time on the signature
+	message, err := ParseSignInMessage(req.Message)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := message.Validate(req.WalletAddress, req.Domain, s.signInPolicy, time.Now()); err != nil {
+		return nil, false, err
+	}
+
 	// Verify signature
 	verified, err := s.walletPort.VerifySignature(ctx, req.WalletAddress, req.Message, req.Signature)
 	if err != nil {
@@ -176,6 +336,16 @@ func (s *Service) VerifyWallet(ctx context.Context, req *VerifyRequest) (*Wallet
 		return nil, false, ErrInvalidSignature
 	}
 
+	// Only consume the challenge once the signature checks out, so invalid
+	// attempts cannot burn someone else's challenge
+	challenge, err := s.noncePort.ConsumeChallenge(ctx, req.Message)
+	if err != nil {
+		return nil, false, err
+	}
+	if challenge.WalletAddress != req.WalletAddress {
+		return nil, false, ErrChallengeNotFound
+	}
+
 	// Get current status
 	status, err := s.GetStatus(ctx, req.WalletAddress)
 	if err != nil {
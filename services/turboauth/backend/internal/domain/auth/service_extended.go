@@ -3,7 +3,9 @@ package auth
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/rs/zerolog/log"
@@ -16,6 +18,7 @@ func (s *Service) CreateSession(ctx context.Context, req *SessionRequest) (*Sess
 		WalletAddress: req.WalletAddress,
 		Signature:     req.Signature,
 		Message:       req.Message,
+		Domain:        req.Domain,
 	}
 
 	status, verified, err := s.VerifyWallet(ctx, verifyReq)
@@ -27,6 +30,10 @@ func (s *Service) CreateSession(ctx context.Context, req *SessionRequest) (*Sess
 		return nil, ErrInvalidSignature
 	}
 
+	if err := s.checkNotBlocked(ctx, req.WalletAddress, status); err != nil {
+		return nil, err
+	}
+
 	// Check rate limit
 	if s.rateLimitPort != nil {
 		limitInfo, err := s.rateLimitPort.CheckRateLimit(ctx, req.WalletAddress)
@@ -41,39 +48,48 @@ func (s *Service) CreateSession(ctx context.Context, req *SessionRequest) (*Sess
 		return nil, err
 	}
 
-	// Set TTL (default 1 hour)
-	ttl := time.Hour
-	if req.TTL > 0 {
-		ttl = time.Duration(req.TTL) * time.Second
-	}
+	ttl := s.sessionPolicy.ttl(req.TTL)
 
-	expiresAt := time.Now().Add(ttl)
-
-	// Generate JWT token
-	var token string
-	if s.tokenPort != nil {
-		token, err = s.tokenPort.GenerateToken(req.WalletAddress, expiresAt)
-		if err != nil {
-			log.Warn().Err(err).Msg("Failed to generate token, continuing without it")
-		}
-	}
+	// The session stores derive its lifetime from these, so use a single clock reading
+	now := time.Now()
+	expiresAt := now.Add(ttl)
 
 	// Create session
 	session := &Session{
 		SessionID:     sessionID,
 		WalletAddress: req.WalletAddress,
-		Token:         token,
 		ExpiresAt:     expiresAt,
-		CreatedAt:     time.Now(),
-		LastActivity:  time.Now(),
+		CreatedAt:     now,
+		LastActivity:  now,
+		IPAddress:     req.IPAddress,
+		UserAgent:     req.UserAgent,
 	}
 
-	// Store session
+	// Generate JWT token
+	token, err := s.issueToken(session, status)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start the refresh-token family before storing the session, so a
+	// failure leaves no session behind that could never be refreshed; a
+	// family whose session was not stored cannot be redeemed
+	var refreshToken string
+	if s.refreshTokenPort != nil {
+		if refreshToken, err = s.issueRefreshToken(ctx, session, ttl); err != nil {
+			return nil, err
+		}
+	}
+
+	// Store session; the tokens are bearer credentials, so they are only
+	// handed to the client and never stored
 	if s.sessionPort != nil {
 		if err := s.sessionPort.CreateSession(ctx, session); err != nil {
 			return nil, err
 		}
 	}
+	session.Token = token
+	session.RefreshToken = refreshToken
 
 	// Send webhook notification
 	if s.webhookPort != nil {
@@ -101,29 +117,227 @@ func (s *Service) CreateSession(ctx context.Context, req *SessionRequest) (*Sess
 	}, nil
 }
 
-// RefreshSession extends an existing session
+// RefreshSession extends the session of a refresh token
+// The refresh token is consumed: the session is returned with the refresh
+// token replacing it and a new access token.
 func (s *Service) RefreshSession(ctx context.Context, req *RefreshSessionRequest) (*Session, error) {
 	if s.sessionPort == nil {
 		return nil, ErrSessionNotFound
 	}
+	if s.refreshTokenPort == nil {
+		return nil, ErrInvalidToken
+	}
 
-	// Validate token if provided
-	if req.Token != "" && s.tokenPort != nil {
-		_, err := s.tokenPort.ValidateToken(req.Token)
-		if err != nil {
-			return nil, ErrInvalidToken
-		}
+	familyID, secret, err := splitRefreshToken(req.RefreshToken)
+	if err != nil {
+		return nil, err
 	}
 
-	// Refresh session
-	session, err := s.sessionPort.RefreshSession(ctx, req.SessionID)
+	// Check the session can be refreshed before rotating, so that a refusal
+	// does not consume the client's refresh token
+	family, err := s.refreshTokenPort.GetFamily(ctx, familyID)
+	if err != nil {
+		return nil, err
+	}
+	status, err := s.GetStatus(ctx, family.WalletAddress)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkNotBlocked(ctx, family.WalletAddress, status); err != nil {
+		return nil, err
+	}
+
+	// Extend the session in the same step, so the rotated family can expire
+	// with it; a session extended by a token that then turns out to be reused
+	// is ended by the rotation anyway
+	session, err := s.sessionPort.RefreshSession(ctx, family.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, session.RefreshToken, err = s.rotateRefreshToken(ctx, familyID, secret, session.ExpiresAt, req); err != nil {
+		return nil, err
+	}
+
+	// The old token expires with the old session lifetime, issue one for the
+	// new lifetime carrying the wallet's current status
+	if session.Token, err = s.issueToken(session, status); err != nil {
+		return nil, err
+	}
 
 	return session, nil
 }
 
+// issueToken signs a token for session, or returns an empty token if no
+// token port is configured
+func (s *Service) issueToken(session *Session, status *WalletAuth) (string, error) {
+	if s.tokenPort == nil {
+		return "", nil
+	}
+
+	token, err := s.tokenPort.GenerateToken(&TokenClaims{
+		WalletAddress: session.WalletAddress,
+		SessionID:     session.SessionID,
+		Status:        status.Status,
+		TrustScore:    status.TrustScore,
+		ExpiresAt:     session.ExpiresAt,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return token, nil
+}
+
+// TokenKeys returns the public keys session tokens can be verified with
+func (s *Service) TokenKeys() []JSONWebKey {
+	if s.tokenPort == nil {
+		return []JSONWebKey{}
+	}
+	return s.tokenPort.PublicKeys()
+}
+
+// DeleteSession ends a session of the caller's wallet and revokes its tokens
+// The caller is identified by its access token; it may end any active session
+// of its wallet by handle.
+func (s *Service) DeleteSession(ctx context.Context, req *DeleteSessionRequest) error {
+	if s.sessionPort == nil {
+		return ErrSessionNotFound
+	}
+
+	claims, err := s.authenticate(ctx, req.AccessToken)
+	if err != nil {
+		return err
+	}
+
+	sessionID, err := s.findSession(ctx, claims.WalletAddress, req.Handle)
+	if err != nil {
+		return err
+	}
+
+	if err := s.sessionPort.DeleteSession(ctx, sessionID); err != nil {
+		return err
+	}
+	if s.revocationPort != nil {
+		if err := s.revocationPort.RevokeSession(ctx, sessionID, time.Now().Add(s.revocationTTL())); err != nil {
+			return err
+		}
+	}
+
+	log.Info().
+		Str("wallet", claims.WalletAddress).
+		Str("session_id", sessionID).
+		Msg("Session deleted")
+	return nil
+}
+
+// GetActiveSessions lists the active sessions of a wallet to the holder of one
+// of its tokens
+// Sessions are listed by handle, without their IDs or tokens; a handle is
+// only good for ending the session with a token of the same wallet.
+func (s *Service) GetActiveSessions(ctx context.Context, req *ActiveSessionsRequest) ([]*Session, error) {
+	if s.sessionPort == nil {
+		return nil, ErrSessionNotFound
+	}
+
+	claims, err := s.authenticateWallet(ctx, req.AccessToken, req.WalletAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessionPort.GetActiveSessions(ctx, req.WalletAddress)
+	if err != nil {
+		return nil, err
+	}
+	for _, session := range sessions {
+		session.Handle = sessionHandle(session.SessionID)
+		session.Current = session.SessionID == claims.SessionID
+		session.SessionID = ""
+		session.Token = ""
+	}
+
+	return sessions, nil
+}
+
+// authenticate validates the access token of a session-protected request and
+// returns its claims
+func (s *Service) authenticate(ctx context.Context, accessToken string) (*TokenClaims, error) {
+	if s.tokenPort == nil {
+		return nil, ErrInvalidToken
+	}
+	if accessToken == "" {
+		return nil, fmt.Errorf("%w: missing access token", ErrInvalidToken)
+	}
+	return s.tokenPort.ValidateToken(ctx, accessToken)
+}
+
+// authenticateWallet is authenticate for a request about walletAddress, whose
+// token must have been issued to that wallet
+func (s *Service) authenticateWallet(ctx context.Context, accessToken, walletAddress string) (*TokenClaims, error) {
+	if !s.walletPort.ValidateAddress(walletAddress) {
+		return nil, ErrInvalidAddress
+	}
+
+	claims, err := s.authenticate(ctx, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if claims.WalletAddress != walletAddress {
+		return nil, fmt.Errorf("%w: token belongs to another wallet", ErrUnauthorized)
+	}
+	return claims, nil
+}
+
+// findSession returns the ID of the active session of a wallet listed under handle
+func (s *Service) findSession(ctx context.Context, walletAddress, handle string) (string, error) {
+	sessions, err := s.sessionPort.GetActiveSessions(ctx, walletAddress)
+	if err != nil {
+		return "", err
+	}
+	for _, session := range sessions {
+		if sessionHandle(session.SessionID) == handle {
+			return session.SessionID, nil
+		}
+	}
+	return "", ErrSessionNotFound
+}
+
+// sessionHandle returns the handle a session is listed under
+// It is derived one-way from the session ID, so it can only be resolved by
+// listing the sessions of the wallet, which takes a token of that wallet.
+func sessionHandle(sessionID string) string {
+	sum := sha256.Sum256([]byte("session handle:" + sessionID))
+	return hex.EncodeToString(sum[:16])
+}
+
+// StartSessionCleanup removes expired sessions every interval until ctx is done
+// It does nothing if no session store is configured.
+func (s *Service) StartSessionCleanup(ctx context.Context, interval time.Duration) {
+	if s.sessionPort == nil || interval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				removed, err := s.sessionPort.CleanupExpiredSessions(ctx)
+				if err != nil {
+					log.Warn().Err(err).Msg("Failed to clean up expired sessions")
+					continue
+				}
+				if removed > 0 {
+					log.Debug().Int("removed", removed).Msg("Expired sessions cleaned up")
+				}
+			}
+		}
+	}()
+}
+
 // BatchVerify verifies multiple wallets in a single request
 func (s *Service) BatchVerify(ctx context.Context, req *BatchVerifyRequest) (*BatchVerifyResponse, error) {
 	results := make([]VerifyResult, len(req.Verifications))
@@ -159,6 +373,14 @@ func generateSessionID() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
+func generateNonce() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
 func generateEventID() string {
 	bytes := make([]byte, 8)
 	rand.Read(bytes)
@@ -0,0 +1,718 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"turboauth/internal/adapters/secondary/noncestore"
+	"turboauth/internal/adapters/secondary/proposalstore"
+	"turboauth/internal/adapters/secondary/refreshstore"
+	"turboauth/internal/adapters/secondary/revocation"
+	"turboauth/internal/adapters/secondary/sessionstore"
+	"turboauth/internal/adapters/secondary/token"
+	"turboauth/internal/adapters/secondary/truststore"
+	"turboauth/internal/adapters/secondary/txstore"
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/identity"
+)
+
+// testDomain is the domain test sign-ins are issued for
+const testDomain = "app.example.com"
+
+var (
+	testAdmin   = identity.FromPublicKey([identity.PublicKeySize]byte{0xad}, false)
+	testWallet  = identity.FromPublicKey([identity.PublicKeySize]byte{0x01}, false)
+	otherWallet = identity.FromPublicKey([identity.PublicKeySize]byte{0x02}, false)
+)
+
+// fakeQubic is a contract holding statuses in memory
+type fakeQubic struct {
+	mu        sync.Mutex
+	statuses  map[string]*auth.WalletAuth
+	submitted []auth.SetStatusRequest
+	err       error
+	failing   map[string]error // per-wallet lookup errors
+	queried   int              // wallets looked up
+	batches   []int            // sizes of the batch queries
+	release   chan struct{}    // when set, lookups wait for it to be closed
+}
+
+func newFakeQubic() *fakeQubic {
+	return &fakeQubic{statuses: make(map[string]*auth.WalletAuth)}
+}
+
+func (f *fakeQubic) setStatus(walletAddress string, status auth.AuthStatus, trustScore int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.statuses[walletAddress] = &auth.WalletAuth{
+		WalletAddress: walletAddress,
+		Status:        status,
+		TrustScore:    trustScore,
+		UpdatedAt:     time.Now(),
+	}
+}
+
+// fail makes every status lookup return err until it is called with nil
+func (f *fakeQubic) fail(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// failWallet makes lookups of walletAddress return err
+func (f *fakeQubic) failWallet(walletAddress string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failing == nil {
+		f.failing = make(map[string]error)
+	}
+	f.failing[walletAddress] = err
+}
+
+// hold makes lookups wait until the returned function is called
+func (f *fakeQubic) hold() (release func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	ch := make(chan struct{})
+	f.release = ch
+	return func() { close(ch) }
+}
+
+// batchSizes returns the sizes of the batch queries made so far, smallest first
+func (f *fakeQubic) batchSizes() []int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Sorted(slices.Values(f.batches))
+}
+
+// lookups returns how many wallets have been looked up
+func (f *fakeQubic) lookups() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.queried
+}
+
+func (f *fakeQubic) GetAuthStatus(ctx context.Context, walletAddress string) (*auth.WalletAuth, error) {
+	f.mu.Lock()
+	f.queried++
+	release := f.release
+	f.mu.Unlock()
+
+	if release != nil {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, f.err
+	}
+	if err := f.failing[walletAddress]; err != nil {
+		return nil, err
+	}
+	status, ok := f.statuses[walletAddress]
+	if !ok {
+		return nil, auth.ErrWalletNotFound
+	}
+	copied := *status
+	return &copied, nil
+}
+
+// SetAuthStatus records the request; like the chain, it does not apply it
+// until the transaction would be confirmed
+func (f *fakeQubic) SetAuthStatus(ctx context.Context, req *auth.SetStatusRequest) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.submitted = append(f.submitted, *req)
+	return fmt.Sprintf("tx%d", len(f.submitted)), nil
+}
+
+func (f *fakeQubic) GetTransactionState(ctx context.Context, txHash string) (*auth.TxResult, error) {
+	return &auth.TxResult{State: auth.TxPending}, nil
+}
+
+func (f *fakeQubic) BatchGetAuthStatus(ctx context.Context, walletAddresses []string) ([]auth.StatusResult, error) {
+	f.mu.Lock()
+	f.batches = append(f.batches, len(walletAddresses))
+	f.mu.Unlock()
+
+	results := make([]auth.StatusResult, len(walletAddresses))
+	for i, addr := range walletAddresses {
+		results[i].WalletAddress = addr
+		results[i].Status, results[i].Err = f.GetAuthStatus(ctx, addr)
+	}
+	return results, nil
+}
+
+func (f *fakeQubic) GetContractAddress() string { return "" }
+
+func (f *fakeQubic) HealthCheck(ctx context.Context) error { return nil }
+
+func (f *fakeQubic) submissions() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.submitted)
+}
+
+// fakeVerifier validates identities and accepts signatures made by sign
+type fakeVerifier struct{}
+
+func (fakeVerifier) VerifySignature(ctx context.Context, walletAddress, message, signature string) (bool, error) {
+	return signature == sign(walletAddress, message), nil
+}
+
+func (fakeVerifier) ValidateAddress(walletAddress string) bool {
+	return identity.Validate(walletAddress) == nil
+}
+
+// testAdminPolicy lets testAdmin change statuses once threshold admins approve
+func testAdminPolicy(threshold int) auth.AdminPolicy {
+	return auth.AdminPolicy{
+		Identities:        []string{testAdmin},
+		MaxRequestAge:     time.Minute,
+		ApprovalThreshold: threshold,
+		ProposalTTL:       time.Hour,
+	}
+}
+
+// newTestService creates a service backed by in-memory stores and qubic;
+// opts override the test policies
+func newTestService(t *testing.T, qubic *fakeQubic, opts ...auth.Option) *auth.Service {
+	t.Helper()
+
+	trustStore := truststore.NewMemoryStore(0, 0)
+	nonceStore := noncestore.NewMemoryStore()
+	proposalStore := proposalstore.NewMemoryStore()
+	statusChangeStore := txstore.NewMemoryStore()
+
+	svc := auth.NewService(
+		qubic,
+		fakeVerifier{},
+		trustStore,
+		nonceStore,
+		proposalStore,
+		statusChangeStore,
+		append([]auth.Option{
+			auth.WithCachePolicy(auth.CachePolicy{TTL: time.Minute, NegativeTTL: time.Minute}),
+			auth.WithSignInPolicy(auth.SignInPolicy{
+				AllowedDomains: []string{testDomain},
+				ChainID:        "mainnet",
+				ChallengeTTL:   time.Minute,
+			}),
+			auth.WithAdminPolicy(testAdminPolicy(1)),
+		}, opts...)...,
+	)
+	t.Cleanup(func() {
+		svc.Stop()
+		trustStore.Stop()
+		nonceStore.Stop()
+		proposalStore.Stop()
+		statusChangeStore.Stop()
+	})
+	return svc
+}
+
+// withSessions returns the options enabling sessions and their tokens, backed
+// by in-memory stores
+func withSessions(t *testing.T) []auth.Option {
+	t.Helper()
+
+	revocations := revocation.NewMemoryStore()
+	t.Cleanup(revocations.Stop)
+	refreshTokens := refreshstore.NewMemoryStore()
+	t.Cleanup(refreshTokens.Stop)
+	issuer, err := token.NewIssuer(token.Config{Issuer: "turboauth-test"}, revocations)
+	if err != nil {
+		t.Fatalf("NewIssuer: %v", err)
+	}
+
+	return []auth.Option{
+		auth.WithSessionPort(sessionstore.NewMemoryStore()),
+		auth.WithRevocationPort(revocations),
+		auth.WithTokenPort(issuer),
+		auth.WithRefreshTokenPort(refreshTokens),
+	}
+}
+
+// signIn creates a session for walletAddress through a signed challenge
+func signIn(t *testing.T, svc *auth.Service, walletAddress string) *auth.Session {
+	t.Helper()
+
+	session, err := trySignIn(t, svc, walletAddress)
+	if err != nil {
+		t.Fatalf("CreateSession: %v", err)
+	}
+	return session
+}
+
+// trySignIn signs a challenge for walletAddress and returns the outcome of
+// creating a session with it
+func trySignIn(t *testing.T, svc *auth.Service, walletAddress string) (*auth.Session, error) {
+	t.Helper()
+	ctx := context.Background()
+
+	challenge, err := svc.CreateChallenge(ctx, &auth.ChallengeRequest{
+		WalletAddress: walletAddress,
+		Domain:        testDomain,
+		URI:           "https://" + testDomain + "/login",
+	})
+	if err != nil {
+		t.Fatalf("CreateChallenge: %v", err)
+	}
+
+	resp, err := svc.CreateSession(ctx, &auth.SessionRequest{
+		WalletAddress: walletAddress,
+		Signature:     sign(walletAddress, challenge.Message),
+		Message:       challenge.Message,
+		Domain:        testDomain,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Session, nil
+}
+
+// sign returns the signature fakeVerifier accepts from signer over message
+func sign(signer, message string) string {
+	return signer + ":" + message
+}
+
+// adminRequest returns a status change signed by the test admin
+func adminRequest(walletAddress string, status auth.AuthStatus, trustScore int, nonce string) *auth.SetStatusRequest {
+	req := &auth.SetStatusRequest{
+		WalletAddress: walletAddress,
+		Status:        status,
+		TrustScore:    trustScore,
+		AdminAddress:  testAdmin,
+		Nonce:         nonce,
+		Timestamp:     time.Now().Unix(),
+	}
+	req.AdminSignature = sign(testAdmin, req.SigningMessage())
+	return req
+}
+
+// proposalRequest returns a status-change proposal signed by the test admin
+func proposalRequest(walletAddress string, status auth.AuthStatus, trustScore int, nonce string) *auth.SetStatusRequest {
+	req := adminRequest(walletAddress, status, trustScore, nonce)
+	req.AdminSignature = sign(testAdmin, req.ProposalMessage())
+	return req
+}
+
+func TestSetStatusValidatesBeforeAuthorizing(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     auth.AuthStatus
+		trustScore int
+		wantErr    error
+	}{
+		{name: "unknown status", status: auth.StatusUnknown, trustScore: 50, wantErr: auth.ErrInvalidStatus},
+		{name: "empty status", status: "", trustScore: 50, wantErr: auth.ErrInvalidStatus},
+		{name: "lowercase status", status: "active", trustScore: 50, wantErr: auth.ErrInvalidStatus},
+		{name: "negative score", status: auth.StatusActive, trustScore: -1, wantErr: auth.ErrInvalidTrustScore},
+		{name: "score above 100", status: auth.StatusActive, trustScore: 101, wantErr: auth.ErrInvalidTrustScore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qubic := newFakeQubic()
+			svc := newTestService(t, qubic)
+			ctx := context.Background()
+
+			_, err := svc.SetStatus(ctx, adminRequest(testWallet, tt.status, tt.trustScore, "nonce"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SetStatus error = %v, want %v", err, tt.wantErr)
+			}
+			if qubic.submissions() != 0 {
+				t.Fatal("invalid status change was submitted")
+			}
+
+			// The rejected request must not have spent the nonce
+			if _, err := svc.SetStatus(ctx, adminRequest(testWallet, auth.StatusActive, 100, "nonce")); err != nil {
+				t.Fatalf("SetStatus with the same nonce: %v", err)
+			}
+		})
+	}
+}
+
+func TestProposeStatusValidatesBeforeAuthorizing(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     auth.AuthStatus
+		trustScore int
+		wantErr    error
+	}{
+		{name: "unknown status", status: auth.StatusUnknown, trustScore: 50, wantErr: auth.ErrInvalidStatus},
+		{name: "negative score", status: auth.StatusBlocked, trustScore: -1, wantErr: auth.ErrInvalidTrustScore},
+		{name: "score above 100", status: auth.StatusBlocked, trustScore: 101, wantErr: auth.ErrInvalidTrustScore},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newTestService(t, newFakeQubic(), auth.WithAdminPolicy(testAdminPolicy(2)))
+			ctx := context.Background()
+
+			_, err := svc.ProposeStatus(ctx, proposalRequest(testWallet, tt.status, tt.trustScore, "nonce"))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ProposeStatus error = %v, want %v", err, tt.wantErr)
+			}
+
+			// The rejected proposal must not have spent the nonce
+			if _, err := svc.ProposeStatus(ctx, proposalRequest(testWallet, auth.StatusBlocked, 0, "nonce")); err != nil {
+				t.Fatalf("ProposeStatus with the same nonce: %v", err)
+			}
+		})
+	}
+}
+
+func TestAdminSignaturesAreNotInterchangeable(t *testing.T) {
+	svc := newTestService(t, newFakeQubic(), auth.WithAdminPolicy(testAdminPolicy(2)))
+	ctx := context.Background()
+
+	// A signed direct status change must not be accepted as a proposal
+	setStatus := adminRequest(testWallet, auth.StatusBlocked, 0, "set")
+	if _, err := svc.ProposeStatus(ctx, setStatus); !errors.Is(err, auth.ErrUnauthorized) {
+		t.Fatalf("ProposeStatus with a SetStatus signature: error = %v, want %v", err, auth.ErrUnauthorized)
+	}
+
+	// Nor a signed proposal as a direct status change
+	proposal := proposalRequest(testWallet, auth.StatusReview, 50, "propose")
+	if _, err := svc.SetStatus(ctx, proposal); !errors.Is(err, auth.ErrUnauthorized) {
+		t.Fatalf("SetStatus with a ProposeStatus signature: error = %v, want %v", err, auth.ErrUnauthorized)
+	}
+
+	if proposal.ProposalMessage() == proposal.SigningMessage() {
+		t.Fatal("proposal and status change signing messages are identical")
+	}
+}
+
+func TestAdminPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		admins    int
+		threshold int
+		wantErr   bool
+	}{
+		{name: "default threshold without admins", admins: 0, threshold: 1},
+		{name: "unset threshold", admins: 1, threshold: 0},
+		{name: "threshold equals admins", admins: 3, threshold: 3},
+		{name: "threshold below admins", admins: 3, threshold: 2},
+		{name: "threshold above admins", admins: 2, threshold: 3, wantErr: true},
+		{name: "multi-admin threshold without admins", admins: 0, threshold: 2, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := auth.AdminPolicy{ApprovalThreshold: tt.threshold}
+			for i := 0; i < tt.admins; i++ {
+				policy.Identities = append(policy.Identities, identity.FromPublicKey([identity.PublicKeySize]byte{byte(i)}, false))
+			}
+
+			err := policy.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSessionManagementRequiresWalletToken(t *testing.T) {
+	qubic := newFakeQubic()
+	qubic.setStatus(testWallet, auth.StatusActive, 80)
+	qubic.setStatus(otherWallet, auth.StatusActive, 80)
+	svc := newTestService(t, qubic, withSessions(t)...)
+	ctx := context.Background()
+
+	first := signIn(t, svc, testWallet)
+	second := signIn(t, svc, testWallet)
+	other := signIn(t, svc, otherWallet)
+
+	list := func(accessToken string) ([]*auth.Session, error) {
+		return svc.GetActiveSessions(ctx, &auth.ActiveSessionsRequest{WalletAddress: testWallet, AccessToken: accessToken})
+	}
+
+	// Neither a missing token nor a session ID is a credential
+	for _, accessToken := range []string{"", first.SessionID} {
+		if _, err := list(accessToken); !errors.Is(err, auth.ErrInvalidToken) {
+			t.Errorf("GetActiveSessions with %q: error = %v, want %v", accessToken, err, auth.ErrInvalidToken)
+		}
+	}
+	if _, err := list(other.Token); !errors.Is(err, auth.ErrUnauthorized) {
+		t.Errorf("GetActiveSessions with another wallet's token: error = %v, want %v", err, auth.ErrUnauthorized)
+	}
+
+	sessions, err := list(first.Token)
+	if err != nil {
+		t.Fatalf("GetActiveSessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("GetActiveSessions returned %d sessions, want 2", len(sessions))
+	}
+	var secondHandle string
+	for _, session := range sessions {
+		if session.SessionID != "" || session.Token != "" {
+			t.Errorf("listed session exposes its credentials: %+v", session)
+		}
+		if session.Handle == "" {
+			t.Error("listed session has no handle")
+		}
+		if session.CreatedAt.Equal(second.CreatedAt) {
+			secondHandle = session.Handle
+		}
+		if session.Current != session.CreatedAt.Equal(first.CreatedAt) {
+			t.Errorf("listed session Current = %v, want it set only on the caller's session", session.Current)
+		}
+	}
+	if secondHandle == "" {
+		t.Fatal("second session not listed")
+	}
+
+	// A handle only resolves among the sessions of the token's wallet
+	err = svc.DeleteSession(ctx, &auth.DeleteSessionRequest{AccessToken: other.Token, Handle: secondHandle})
+	if !errors.Is(err, auth.ErrSessionNotFound) {
+		t.Errorf("DeleteSession of another wallet's session: error = %v, want %v", err, auth.ErrSessionNotFound)
+	}
+	if err := svc.DeleteSession(ctx, &auth.DeleteSessionRequest{AccessToken: first.Token}); !errors.Is(err, auth.ErrSessionNotFound) {
+		t.Errorf("DeleteSession without a handle: error = %v, want %v", err, auth.ErrSessionNotFound)
+	}
+
+	if err := svc.DeleteSession(ctx, &auth.DeleteSessionRequest{AccessToken: first.Token, Handle: secondHandle}); err != nil {
+		t.Fatalf("DeleteSession by handle: %v", err)
+	}
+	if _, err := list(second.Token); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("token of the ended session: error = %v, want %v", err, auth.ErrInvalidToken)
+	}
+	sessions, err = list(first.Token)
+	if err != nil {
+		t.Fatalf("GetActiveSessions: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].Handle == secondHandle {
+		t.Errorf("sessions after DeleteSession = %+v, want only the first", sessions)
+	}
+}
+
+func TestBlockedWalletCannotSignInOrRefresh(t *testing.T) {
+	qubic := newFakeQubic()
+	qubic.setStatus(testWallet, auth.StatusActive, 80)
+	qubic.setStatus(otherWallet, auth.StatusBlocked, 0)
+	svc := newTestService(t, qubic, withSessions(t)...)
+	ctx := context.Background()
+
+	if _, err := trySignIn(t, svc, otherWallet); !errors.Is(err, auth.ErrWalletBlocked) {
+		t.Errorf("sign-in of a wallet blocked on chain: error = %v, want %v", err, auth.ErrWalletBlocked)
+	}
+
+	session := signIn(t, svc, testWallet)
+
+	// The chain keeps reporting ACTIVE until the block is confirmed
+	if _, err := svc.SetStatus(ctx, adminRequest(testWallet, auth.StatusBlocked, 0, "block")); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+
+	if _, err := trySignIn(t, svc, testWallet); !errors.Is(err, auth.ErrWalletBlocked) {
+		t.Errorf("sign-in while the block is pending: error = %v, want %v", err, auth.ErrWalletBlocked)
+	}
+	_, err := svc.RefreshSession(ctx, &auth.RefreshSessionRequest{RefreshToken: session.RefreshToken})
+	if !errors.Is(err, auth.ErrWalletBlocked) {
+		t.Errorf("refresh while the block is pending: error = %v, want %v", err, auth.ErrWalletBlocked)
+	}
+
+	// Changing the status back lifts the block
+	if _, err := svc.SetStatus(ctx, adminRequest(testWallet, auth.StatusActive, 80, "unblock")); err != nil {
+		t.Fatalf("SetStatus: %v", err)
+	}
+	if _, err := trySignIn(t, svc, testWallet); err != nil {
+		t.Errorf("sign-in after unblocking: %v", err)
+	}
+}
+
+// errSessionsDown is returned by unavailableSessions while it is down
+var errSessionsDown = errors.New("session store unavailable")
+
+// unavailableSessions is a session store whose refreshes fail while it is down
+type unavailableSessions struct {
+	*sessionstore.MemoryStore
+	down atomic.Bool
+}
+
+func (u *unavailableSessions) RefreshSession(ctx context.Context, sessionID string) (*auth.Session, error) {
+	if u.down.Load() {
+		return nil, errSessionsDown
+	}
+	return u.MemoryStore.RefreshSession(ctx, sessionID)
+}
+
+func TestFailedRefreshKeepsRefreshToken(t *testing.T) {
+	qubic := newFakeQubic()
+	qubic.setStatus(testWallet, auth.StatusActive, 80)
+	sessions := &unavailableSessions{MemoryStore: sessionstore.NewMemoryStore()}
+	svc := newTestService(t, qubic, append(withSessions(t), auth.WithSessionPort(sessions))...)
+	ctx := context.Background()
+
+	session := signIn(t, svc, testWallet)
+	refresh := func(refreshToken string) (*auth.Session, error) {
+		return svc.RefreshSession(ctx, &auth.RefreshSessionRequest{RefreshToken: refreshToken})
+	}
+
+	sessions.down.Store(true)
+	if _, err := refresh(session.RefreshToken); !errors.Is(err, errSessionsDown) {
+		t.Fatalf("refresh while the session store is down: error = %v, want %v", err, errSessionsDown)
+	}
+	sessions.down.Store(false)
+
+	// The failed refresh did not consume the token
+	refreshed, err := refresh(session.RefreshToken)
+	if err != nil {
+		t.Fatalf("refresh after a failed one: %v", err)
+	}
+	if refreshed.RefreshToken == "" || refreshed.RefreshToken == session.RefreshToken {
+		t.Fatalf("refresh did not rotate the refresh token: %q", refreshed.RefreshToken)
+	}
+
+	if _, err := refresh(session.RefreshToken); !errors.Is(err, auth.ErrRefreshTokenReused) {
+		t.Errorf("refresh with the rotated token: error = %v, want %v", err, auth.ErrRefreshTokenReused)
+	}
+}
+
+// errFamiliesDown is returned by failingRefreshTokens
+var errFamiliesDown = errors.New("refresh token store unavailable")
+
+// failingRefreshTokens is a refresh token store that cannot store families
+type failingRefreshTokens struct {
+	*refreshstore.MemoryStore
+}
+
+func (failingRefreshTokens) CreateFamily(ctx context.Context, family *auth.RefreshFamily) error {
+	return errFamiliesDown
+}
+
+func TestFailedRefreshFamilyStoresNoSession(t *testing.T) {
+	qubic := newFakeQubic()
+	qubic.setStatus(testWallet, auth.StatusActive, 80)
+	sessions := sessionstore.NewMemoryStore()
+	refreshTokens := refreshstore.NewMemoryStore()
+	t.Cleanup(refreshTokens.Stop)
+	svc := newTestService(t, qubic, append(withSessions(t),
+		auth.WithSessionPort(sessions),
+		auth.WithRefreshTokenPort(failingRefreshTokens{refreshTokens}),
+	)...)
+
+	if _, err := trySignIn(t, svc, testWallet); !errors.Is(err, errFamiliesDown) {
+		t.Fatalf("sign-in without a refresh token: error = %v, want %v", err, errFamiliesDown)
+	}
+
+	active, err := sessions.GetActiveSessions(context.Background(), testWallet)
+	if err != nil {
+		t.Fatalf("GetActiveSessions: %v", err)
+	}
+	if len(active) != 0 {
+		t.Errorf("failed sign-in left %d sessions, want none", len(active))
+	}
+}
+
+func TestRefreshFamilyExpiresWithSession(t *testing.T) {
+	qubic := newFakeQubic()
+	qubic.setStatus(testWallet, auth.StatusActive, 80)
+	refreshTokens := refreshstore.NewMemoryStore()
+	t.Cleanup(refreshTokens.Stop)
+	svc := newTestService(t, qubic, append(withSessions(t), auth.WithRefreshTokenPort(refreshTokens))...)
+	ctx := context.Background()
+
+	session := signIn(t, svc, testWallet)
+	for i := 0; i < 3; i++ {
+		refreshed, err := svc.RefreshSession(ctx, &auth.RefreshSessionRequest{RefreshToken: session.RefreshToken})
+		if err != nil {
+			t.Fatalf("RefreshSession: %v", err)
+		}
+		session = refreshed
+
+		familyID, _, _ := strings.Cut(session.RefreshToken, ".")
+		family, err := refreshTokens.GetFamily(ctx, familyID)
+		if err != nil {
+			t.Fatalf("GetFamily: %v", err)
+		}
+		if family.ExpiresAt.After(session.ExpiresAt) {
+			t.Errorf("refresh token family expires at %v, after its session at %v", family.ExpiresAt, session.ExpiresAt)
+		}
+	}
+}
+
+func TestBatchGetStatus(t *testing.T) {
+	qubic := newFakeQubic()
+	failingWallet := identity.FromPublicKey([identity.PublicKeySize]byte{0x03}, false)
+	qubic.setStatus(testWallet, auth.StatusActive, 80)
+	qubic.setStatus(otherWallet, auth.StatusBlocked, 0)
+	qubic.failWallet(failingWallet, auth.ErrBlockchainFailure)
+	svc := newTestService(t, qubic)
+
+	results, err := svc.BatchGetStatus(context.Background(), []string{
+		otherWallet, testWallet, failingWallet, otherWallet, "not-a-wallet", testWallet,
+	})
+	if err != nil {
+		t.Fatalf("BatchGetStatus: %v", err)
+	}
+
+	// One result per distinct wallet, in the order first given, and a wallet
+	// that could not be looked up does not fail the others
+	want := []struct {
+		wallet string
+		status auth.AuthStatus
+		err    error
+	}{
+		{wallet: otherWallet, status: auth.StatusBlocked},
+		{wallet: testWallet, status: auth.StatusActive},
+		{wallet: failingWallet, err: auth.ErrBlockchainFailure},
+		{wallet: "not-a-wallet", err: auth.ErrInvalidAddress},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("BatchGetStatus returned %d results, want %d: %+v", len(results), len(want), results)
+	}
+	for i, w := range want {
+		got := results[i]
+		if got.WalletAddress != w.wallet {
+			t.Errorf("result %d is for %s, want %s", i, got.WalletAddress, w.wallet)
+			continue
+		}
+		if w.err != nil {
+			if !errors.Is(got.Err, w.err) {
+				t.Errorf("%s: error = %v, want %v", w.wallet, got.Err, w.err)
+			}
+			continue
+		}
+		if got.Err != nil || got.Status.Status != w.status {
+			t.Errorf("%s: result = %+v, %v, want %s", w.wallet, got.Status, got.Err, w.status)
+		}
+	}
+
+	// Each valid wallet was looked up once
+	if got := qubic.lookups(); got != 3 {
+		t.Errorf("blockchain queries = %d, want 3", got)
+	}
+}
+
+func TestBatchGetStatusSizeLimit(t *testing.T) {
+	svc := newTestService(t, newFakeQubic())
+	wallets := testWallets(auth.MaxBatchSize + 1)
+
+	results, err := svc.BatchGetStatus(context.Background(), wallets[:auth.MaxBatchSize])
+	if err != nil {
+		t.Fatalf("BatchGetStatus of %d wallets: %v", auth.MaxBatchSize, err)
+	}
+	if len(results) != auth.MaxBatchSize {
+		t.Errorf("BatchGetStatus of %d wallets returned %d results", auth.MaxBatchSize, len(results))
+	}
+
+	if _, err := svc.BatchGetStatus(context.Background(), wallets); !errors.Is(err, auth.ErrBatchTooLarge) {
+		t.Errorf("BatchGetStatus of %d wallets: error = %v, want %v", len(wallets), err, auth.ErrBatchTooLarge)
+	}
+}
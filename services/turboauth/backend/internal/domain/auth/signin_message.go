@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SignInMessageVersion is the current version of the sign-in message format
+const SignInMessageVersion = "1"
+
+// signInHeaderSuffix follows the domain on the first line of a sign-in message
+const signInHeaderSuffix = " wants you to sign in with your Qubic identity:"
+
+// maxClockSkew is how far in the future a message's issued-at time may be
+const maxClockSkew = time.Minute
+
+// SignInMessage is the structured message a wallet signs to sign in.
+// Rendered as text it looks like:
+//
+//	app.example.com wants you to sign in with your Qubic identity:
+//	BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK
+//
+//	URI: https://app.example.com/login
+//	Version: 1
+//	Chain ID: mainnet
+//	Nonce: 5f0c6b1e8a2d4c3b9e7f1a2b3c4d5e6f
+//	Issued At: 2025-01-01T12:00:00Z
+//	Expiration Time: 2025-01-01T12:05:00Z
+//	Scopes: profile, sessions
+//
+// The Scopes line is omitted when no scopes are requested.
+type SignInMessage struct {
+	Domain        string
+	WalletAddress string
+	URI           string
+	Version       string
+	ChainID       string
+	Nonce         string
+	IssuedAt      time.Time
+	ExpiresAt     time.Time
+	Scopes        []string
+}
+
+// SignInPolicy configures which sign-in messages the service issues and accepts
+type SignInPolicy struct {
+	AllowedDomains []string
+	ChainID        string
+	ChallengeTTL   time.Duration
+}
+
+// String renders the message in its canonical text form
+func (m *SignInMessage) String() string {
+	var b strings.Builder
+	b.WriteString(m.Domain + signInHeaderSuffix + "\n")
+	b.WriteString(m.WalletAddress + "\n")
+	b.WriteString("\n")
+	b.WriteString("URI: " + m.URI + "\n")
+	b.WriteString("Version: " + m.Version + "\n")
+	b.WriteString("Chain ID: " + m.ChainID + "\n")
+	b.WriteString("Nonce: " + m.Nonce + "\n")
+	b.WriteString("Issued At: " + m.IssuedAt.UTC().Format(time.RFC3339) + "\n")
+	b.WriteString("Expiration Time: " + m.ExpiresAt.UTC().Format(time.RFC3339))
+	if len(m.Scopes) > 0 {
+		b.WriteString("\nScopes: " + strings.Join(m.Scopes, ", "))
+	}
+	return b.String()
+}
+
+// ParseSignInMessage parses a sign-in message
+// Only the canonical form produced by String is accepted, so two different
+// texts can never describe the same sign-in.
+func ParseSignInMessage(message string) (*SignInMessage, error) {
+	lines := strings.Split(message, "\n")
+	if len(lines) < 9 || len(lines) > 10 {
+		return nil, fmt.Errorf("%w: unexpected number of lines", ErrInvalidMessage)
+	}
+	for _, line := range lines {
+		if strings.TrimRight(line, " \t\r") != line {
+			return nil, fmt.Errorf("%w: trailing whitespace", ErrInvalidMessage)
+		}
+	}
+
+	domain, ok := strings.CutSuffix(lines[0], signInHeaderSuffix)
+	if !ok || domain == "" {
+		return nil, fmt.Errorf("%w: missing header", ErrInvalidMessage)
+	}
+	if lines[2] != "" {
+		return nil, fmt.Errorf("%w: missing blank line after wallet", ErrInvalidMessage)
+	}
+
+	m := &SignInMessage{
+		Domain:        domain,
+		WalletAddress: lines[1],
+	}
+
+	fields := []struct {
+		name  string
+		value *string
+	}{
+		{"URI", &m.URI},
+		{"Version", &m.Version},
+		{"Chain ID", &m.ChainID},
+		{"Nonce", &m.Nonce},
+	}
+	for i, field := range fields {
+		value, err := parseField(lines[3+i], field.name)
+		if err != nil {
+			return nil, err
+		}
+		*field.value = value
+	}
+
+	var err error
+	if m.IssuedAt, err = parseTimeField(lines[7], "Issued At"); err != nil {
+		return nil, err
+	}
+	if m.ExpiresAt, err = parseTimeField(lines[8], "Expiration Time"); err != nil {
+		return nil, err
+	}
+
+	if len(lines) == 10 {
+		scopes, err := parseField(lines[9], "Scopes")
+		if err != nil {
+			return nil, err
+		}
+		m.Scopes = strings.Split(scopes, ", ")
+		for _, scope := range m.Scopes {
+			if scope == "" || strings.ContainsAny(scope, ", \t") {
+				return nil, fmt.Errorf("%w: invalid scope %q", ErrInvalidMessage, scope)
+			}
+		}
+	}
+
+	if m.String() != message {
+		return nil, fmt.Errorf("%w: not in canonical form", ErrInvalidMessage)
+	}
+
+	return m, nil
+}
+
+// Validate checks the message against the wallet that signed it and the
+// sign-in policy. If audience is set, the message domain must equal it.
+func (m *SignInMessage) Validate(walletAddress, audience string, policy SignInPolicy, now time.Time) error {
+	if m.Version != SignInMessageVersion {
+		return fmt.Errorf("%w: unsupported version %q", ErrInvalidMessage, m.Version)
+	}
+	if m.WalletAddress != walletAddress {
+		return fmt.Errorf("%w: message is for a different wallet", ErrInvalidMessage)
+	}
+	if m.ChainID != policy.ChainID {
+		return fmt.Errorf("%w: message is for chain %q", ErrInvalidMessage, m.ChainID)
+	}
+	if !policy.allowsDomain(m.Domain) {
+		return ErrDomainNotAllowed
+	}
+	if audience != "" && !strings.EqualFold(m.Domain, audience) {
+		return fmt.Errorf("%w: message is for domain %q", ErrDomainNotAllowed, m.Domain)
+	}
+	if m.IssuedAt.After(now.Add(maxClockSkew)) {
+		return fmt.Errorf("%w: issued in the future", ErrInvalidMessage)
+	}
+	if !now.Before(m.ExpiresAt) {
+		return ErrChallengeExpired
+	}
+	return nil
+}
+
+// allowsDomain reports whether challenges may be issued for or accepted from domain
+func (p SignInPolicy) allowsDomain(domain string) bool {
+	for _, allowed := range p.AllowedDomains {
+		if strings.EqualFold(allowed, domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSignInRequest rejects values that would break the line-based format
+func validateSignInRequest(req *ChallengeRequest) error {
+	if req.URI == "" || strings.ContainsAny(req.URI, "\r\n") {
+		return fmt.Errorf("%w: invalid URI", ErrInvalidMessage)
+	}
+	for _, scope := range req.Scopes {
+		if scope == "" || strings.ContainsAny(scope, ", \t\r\n") {
+			return fmt.Errorf("%w: invalid scope %q", ErrInvalidMessage, scope)
+		}
+	}
+	return nil
+}
+
+func parseField(line, name string) (string, error) {
+	value, ok := strings.CutPrefix(line, name+": ")
+	if !ok || value == "" {
+		return "", fmt.Errorf("%w: missing %s", ErrInvalidMessage, name)
+	}
+	return value, nil
+}
+
+func parseTimeField(line, name string) (time.Time, error) {
+	value, err := parseField(line, name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: invalid %s", ErrInvalidMessage, name)
+	}
+	return t, nil
+}
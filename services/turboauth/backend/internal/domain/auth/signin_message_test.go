@@ -0,0 +1,246 @@
+package auth_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"turboauth/internal/domain/auth"
+)
+
+// testSignInPolicy accepts messages for testDomain on mainnet
+var testSignInPolicy = auth.SignInPolicy{
+	AllowedDomains: []string{testDomain},
+	ChainID:        "mainnet",
+	ChallengeTTL:   5 * time.Minute,
+}
+
+// testMessage returns a message for testWallet issued at issuedAt
+func testMessage(issuedAt time.Time) *auth.SignInMessage {
+	return &auth.SignInMessage{
+		Domain:        testDomain,
+		WalletAddress: testWallet,
+		URI:           "https://" + testDomain + "/login",
+		Version:       auth.SignInMessageVersion,
+		ChainID:       "mainnet",
+		Nonce:         "5f0c6b1e8a2d4c3b9e7f1a2b3c4d5e6f",
+		IssuedAt:      issuedAt.UTC().Truncate(time.Second),
+		ExpiresAt:     issuedAt.UTC().Truncate(time.Second).Add(testSignInPolicy.ChallengeTTL),
+	}
+}
+
+func TestSignInMessageRoundTrip(t *testing.T) {
+	tests := []struct {
+		name   string
+		scopes []string
+	}{
+		{name: "without scopes"},
+		{name: "with one scope", scopes: []string{"profile"}},
+		{name: "with scopes", scopes: []string{"profile", "sessions"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := testMessage(time.Now())
+			want.Scopes = tt.scopes
+
+			got, err := auth.ParseSignInMessage(want.String())
+			if err != nil {
+				t.Fatalf("ParseSignInMessage: %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ParseSignInMessage = %+v, want %+v", got, want)
+			}
+			if got.String() != want.String() {
+				t.Errorf("String after parsing = %q, want %q", got.String(), want.String())
+			}
+		})
+	}
+}
+
+func TestParseSignInMessageRejectsNonCanonicalText(t *testing.T) {
+	message := testMessage(time.Now())
+	message.Scopes = []string{"profile"}
+	canonical := message.String()
+	lines := strings.Split(canonical, "\n")
+
+	// withLines returns the canonical message with its lines replaced by edit
+	withLines := func(edit func(lines []string) []string) string {
+		return strings.Join(edit(append([]string(nil), lines...)), "\n")
+	}
+
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{
+			name: "reordered lines",
+			message: withLines(func(l []string) []string {
+				l[4], l[5] = l[5], l[4]
+				return l
+			}),
+		},
+		{
+			name: "extra line",
+			message: withLines(func(l []string) []string {
+				return append(l[:9], append([]string{"Statement: sign in"}, l[9:]...)...)
+			}),
+		},
+		{
+			name:    "extra trailing line",
+			message: canonical + "\nResources: none",
+		},
+		{
+			name:    "trailing newline",
+			message: canonical + "\n",
+		},
+		{
+			name:    "trailing whitespace on the last line",
+			message: canonical + " ",
+		},
+		{
+			name: "trailing whitespace inside",
+			message: withLines(func(l []string) []string {
+				l[3] += " "
+				return l
+			}),
+		},
+		{
+			name:    "carriage returns",
+			message: strings.ReplaceAll(canonical, "\n", "\r\n"),
+		},
+		{
+			name: "missing blank line",
+			message: withLines(func(l []string) []string {
+				return append(l[:2], l[3:]...)
+			}),
+		},
+		{
+			name: "scopes without the canonical separator",
+			message: withLines(func(l []string) []string {
+				l[9] = "Scopes: profile,sessions"
+				return l
+			}),
+		},
+		{
+			name: "non-canonical timestamp",
+			message: withLines(func(l []string) []string {
+				l[7] = "Issued At: " + message.IssuedAt.In(time.FixedZone("CET", 3600)).Format(time.RFC3339)
+				return l
+			}),
+		},
+		{
+			name:    "empty",
+			message: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := auth.ParseSignInMessage(tt.message); !errors.Is(err, auth.ErrInvalidMessage) {
+				t.Errorf("ParseSignInMessage error = %v, want %v", err, auth.ErrInvalidMessage)
+			}
+		})
+	}
+}
+
+func TestSignInMessageValidate(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	skew := time.Minute
+
+	tests := []struct {
+		name     string
+		edit     func(m *auth.SignInMessage)
+		wallet   string
+		audience string
+		want     error
+	}{
+		{
+			name: "valid",
+		},
+		{
+			name:     "valid for its audience",
+			audience: strings.ToUpper(testDomain),
+		},
+		{
+			name:   "wrong wallet",
+			wallet: otherWallet,
+			want:   auth.ErrInvalidMessage,
+		},
+		{
+			name: "domain outside the policy",
+			edit: func(m *auth.SignInMessage) { m.Domain = "evil.example.com" },
+			want: auth.ErrDomainNotAllowed,
+		},
+		{
+			name:     "domain other than the audience",
+			audience: "other.example.com",
+			want:     auth.ErrDomainNotAllowed,
+		},
+		{
+			name: "wrong chain ID",
+			edit: func(m *auth.SignInMessage) { m.ChainID = "testnet" },
+			want: auth.ErrInvalidMessage,
+		},
+		{
+			name: "unsupported version",
+			edit: func(m *auth.SignInMessage) { m.Version = "2" },
+			want: auth.ErrInvalidMessage,
+		},
+		{
+			name: "issued in the future within the clock skew",
+			edit: func(m *auth.SignInMessage) {
+				m.IssuedAt = now.Add(skew - time.Second)
+				m.ExpiresAt = m.IssuedAt.Add(time.Minute)
+			},
+		},
+		{
+			name: "issued in the future beyond the clock skew",
+			edit: func(m *auth.SignInMessage) {
+				m.IssuedAt = now.Add(skew + time.Second)
+				m.ExpiresAt = m.IssuedAt.Add(time.Minute)
+			},
+			want: auth.ErrInvalidMessage,
+		},
+		{
+			name: "expires in a second",
+			edit: func(m *auth.SignInMessage) { m.ExpiresAt = now.Add(time.Second) },
+		},
+		{
+			name: "expires now",
+			edit: func(m *auth.SignInMessage) { m.ExpiresAt = now },
+			want: auth.ErrChallengeExpired,
+		},
+		{
+			name: "expired",
+			edit: func(m *auth.SignInMessage) {
+				m.IssuedAt = now.Add(-time.Hour)
+				m.ExpiresAt = now.Add(-time.Hour + time.Minute)
+			},
+			want: auth.ErrChallengeExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := testMessage(now)
+			if tt.edit != nil {
+				tt.edit(message)
+			}
+			wallet := tt.wallet
+			if wallet == "" {
+				wallet = testWallet
+			}
+
+			err := message.Validate(wallet, tt.audience, testSignInPolicy, now)
+			if tt.want == nil && err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+			if tt.want != nil && !errors.Is(err, tt.want) {
+				t.Errorf("Validate error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
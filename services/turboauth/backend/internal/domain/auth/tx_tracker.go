@@ -0,0 +1,206 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"turboauth/pkg/metrics"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// txPollInterval is how often pending transactions are checked
+	txPollInterval = 5 * time.Second
+
+	// txCheckTimeout bounds the node calls made to check one transaction
+	txCheckTimeout = 5 * time.Second
+
+	// txConfirmTimeout is how long a transaction may stay pending before it is
+	// considered expired, regardless of what the node reports
+	txConfirmTimeout = 5 * time.Minute
+)
+
+// errTxFinished aborts an update of a status change another instance has
+// already finished
+var errTxFinished = errors.New("transaction already finished")
+
+// TxTracker follows submitted status-change transactions until they are
+// included on-chain or expire, and invalidates the trust store once the
+// change has actually landed
+// Status changes are kept in a StatusChangePort, so they can be queried on
+// every instance. Every instance polls all pending changes; the first to see
+// a change finish records it.
+type TxTracker struct {
+	qubicPort      QubicPort
+	trustStorePort TrustStorePort
+	changes        StatusChangePort
+
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewTxTracker creates a new transaction confirmation tracker and starts
+// polling; Stop ends it
+func NewTxTracker(qubicPort QubicPort, trustStorePort TrustStorePort, changes StatusChangePort) *TxTracker {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &TxTracker{
+		qubicPort:      qubicPort,
+		trustStorePort: trustStorePort,
+		changes:        changes,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+
+	go t.run()
+
+	return t
+}
+
+// Track starts following a submitted status-change transaction
+func (t *TxTracker) Track(ctx context.Context, txHash string, req *SetStatusRequest) (*StatusChange, error) {
+	now := time.Now()
+	change := &StatusChange{
+		TxHash:        txHash,
+		WalletAddress: req.WalletAddress,
+		Status:        req.Status,
+		TrustScore:    req.TrustScore,
+		State:         TxPending,
+		SubmittedAt:   now,
+		UpdatedAt:     now,
+	}
+
+	if err := t.changes.CreateStatusChange(ctx, change); err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// Get returns the current state of a tracked transaction
+func (t *TxTracker) Get(ctx context.Context, txHash string) (*StatusChange, error) {
+	return t.changes.GetStatusChange(ctx, txHash)
+}
+
+// Stop ends polling and aborts checks in progress
+func (t *TxTracker) Stop() {
+	t.cancel()
+}
+
+// run polls pending transactions periodically until the tracker is stopped
+func (t *TxTracker) run() {
+	ticker := time.NewTicker(txPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-ticker.C:
+			t.poll()
+		}
+	}
+}
+
+// poll checks every pending transaction once
+func (t *TxTracker) poll() {
+	ctx, cancel := context.WithTimeout(t.ctx, txCheckTimeout)
+	pending, err := t.changes.PendingStatusChanges(ctx)
+	cancel()
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to list pending transactions")
+		return
+	}
+
+	for _, change := range pending {
+		if t.ctx.Err() != nil {
+			return
+		}
+		t.check(change)
+	}
+}
+
+// check asks the node about one pending transaction and records its outcome
+// Each check has its own timeout, so a slow node call for one transaction
+// does not use up the time of the others.
+func (t *TxTracker) check(change *StatusChange) {
+	ctx, cancel := context.WithTimeout(t.ctx, txCheckTimeout)
+	defer cancel()
+
+	result, err := t.qubicPort.GetTransactionState(ctx, change.TxHash)
+	if err != nil {
+		log.Warn().Err(err).Str("tx_hash", change.TxHash).Msg("Failed to check transaction state")
+		result = &TxResult{State: TxPending}
+	}
+
+	if result.State == TxConfirmed {
+		// Inclusion does not mean the contract applied the change: setStatus
+		// rejects invalid input without failing the transaction
+		applied, err := t.applied(ctx, change)
+		if err != nil {
+			log.Warn().Err(err).Str("tx_hash", change.TxHash).Msg("Failed to read back status change")
+			result = &TxResult{State: TxPending}
+		} else if !applied {
+			result = &TxResult{State: TxFailed, Reason: "transaction was included but the contract did not apply the change"}
+		}
+	}
+
+	switch {
+	case result.State == TxConfirmed:
+		// The change is on-chain now; anything cached since submission is stale
+		if err := t.trustStorePort.Delete(ctx, change.WalletAddress); err != nil {
+			log.Warn().Err(err).Str("wallet", change.WalletAddress).Msg("Failed to invalidate cache")
+		}
+		t.finish(ctx, change.TxHash, TxConfirmed, "")
+	case result.State == TxFailed:
+		t.finish(ctx, change.TxHash, TxFailed, result.Reason)
+	case time.Since(change.SubmittedAt) > txConfirmTimeout:
+		t.finish(ctx, change.TxHash, TxFailed, "transaction confirmation timed out")
+	}
+}
+
+// applied reports whether the contract now holds the status a change set
+func (t *TxTracker) applied(ctx context.Context, change *StatusChange) (bool, error) {
+	current, err := t.qubicPort.GetAuthStatus(ctx, change.WalletAddress)
+	if errors.Is(err, ErrWalletNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return current.Status == change.Status && current.TrustScore == change.TrustScore, nil
+}
+
+// finish records the final state of a transaction, unless another instance
+// already has
+func (t *TxTracker) finish(ctx context.Context, txHash string, state TxState, reason string) {
+	change, err := t.changes.UpdateStatusChange(ctx, txHash, func(change *StatusChange) error {
+		if change.State != TxPending {
+			return errTxFinished
+		}
+		change.State = state
+		change.Error = reason
+		change.UpdatedAt = time.Now()
+		return nil
+	})
+	if errors.Is(err, errTxFinished) || errors.Is(err, ErrTxNotFound) {
+		return
+	}
+	if err != nil {
+		log.Warn().Err(err).Str("tx_hash", txHash).Msg("Failed to record transaction state")
+		return
+	}
+
+	metrics.BlockchainRequestsTotal.WithLabelValues("tx_confirmation", strings.ToLower(string(state))).Inc()
+
+	event := log.Info()
+	if state == TxFailed {
+		event = log.Warn().Str("reason", reason)
+	}
+	event.
+		Str("tx_hash", txHash).
+		Str("wallet", change.WalletAddress).
+		Str("state", string(state)).
+		Msg("Status change finished")
+}
@@ -0,0 +1,257 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"turboauth/pkg/metrics"
+
+	"github.com/rs/zerolog/log"
+)
+
+// WarmupPolicy configures how the trust store is prefetched
+type WarmupPolicy struct {
+	// MaxWallets caps how many wallets are prefetched in one run
+	MaxWallets int
+
+	// BatchSize is how many wallets are fetched per blockchain batch query
+	BatchSize int
+
+	// Concurrency bounds the batch queries in flight
+	Concurrency int
+
+	// Timeout bounds a run, so a slow node cannot hold back readiness forever
+	Timeout time.Duration
+}
+
+// WarmupRequest asks for a warm-up run on behalf of an admin
+// AdminSignature is the admin identity's signature over SigningMessage().
+type WarmupRequest struct {
+	AdminAddress   string `json:"admin_address" validate:"required"`
+	Nonce          string `json:"nonce" validate:"required"`
+	Timestamp      int64  `json:"timestamp" validate:"required"`
+	AdminSignature string `json:"admin_signature" validate:"required"`
+}
+
+// SigningMessage returns the canonical text an admin signs to trigger a
+// warm-up run:
+//
+//	TurboAuth Warmup
+//	Nonce: 7d1f0c2e
+//	Timestamp: 1735732800
+func (r *WarmupRequest) SigningMessage() string {
+	return fmt.Sprintf("TurboAuth Warmup\nNonce: %s\nTimestamp: %d", r.Nonce, r.Timestamp)
+}
+
+// WarmupResult summarizes a warm-up run
+type WarmupResult struct {
+	Wallets  int           `json:"wallets"` // distinct valid wallets listed by the sources
+	Cached   int           `json:"cached"`  // already in the trust store
+	Fetched  int           `json:"fetched"` // loaded from the blockchain
+	Failed   int           `json:"failed"`  // not found or not fetched
+	Duration time.Duration `json:"duration"`
+}
+
+// Warmer prefetches the wallets listed by its sources into the trust store,
+// so a freshly started instance does not send its first minutes of traffic to
+// the blockchain
+type Warmer struct {
+	service *Service
+	policy  WarmupPolicy
+	sources []WarmupSourcePort
+
+	ready   atomic.Bool
+	running atomic.Bool
+}
+
+// NewWarmer creates a warmer reading wallets from sources in order
+func NewWarmer(service *Service, policy WarmupPolicy, sources ...WarmupSourcePort) *Warmer {
+	if policy.BatchSize < 1 {
+		policy.BatchSize = 100
+	}
+	if policy.Concurrency < 1 {
+		policy.Concurrency = 1
+	}
+
+	w := &Warmer{
+		service: service,
+		policy:  policy,
+		sources: sources,
+	}
+	if len(sources) == 0 {
+		w.ready.Store(true)
+	}
+	return w
+}
+
+// Ready reports whether the startup warm-up has finished
+func (w *Warmer) Ready() bool {
+	return w.ready.Load()
+}
+
+// Start runs the startup warm-up in the background; the warmer reports ready
+// once it has finished, failed or timed out
+func (w *Warmer) Start() {
+	if w.Ready() {
+		return
+	}
+
+	go func() {
+		defer w.ready.Store(true)
+
+		result, err := w.Run(context.Background())
+		if err != nil {
+			log.Warn().Err(err).Msg("Cache warm-up failed")
+			return
+		}
+		log.Info().
+			Int("wallets", result.Wallets).
+			Int("cached", result.Cached).
+			Int("fetched", result.Fetched).
+			Int("failed", result.Failed).
+			Dur("duration", result.Duration).
+			Msg("Cache warm-up finished")
+	}()
+}
+
+// Trigger starts a warm-up run in the background on behalf of an admin
+func (w *Warmer) Trigger(ctx context.Context, req *WarmupRequest) error {
+	if err := w.service.authorizeAdminSignature(ctx, req.AdminAddress, req.Nonce, req.Timestamp, req.AdminSignature, req.SigningMessage()); err != nil {
+		log.Warn().Err(err).Str("admin", req.AdminAddress).Msg("Rejected cache warm-up")
+		return err
+	}
+
+	if !w.running.CompareAndSwap(false, true) {
+		return ErrWarmupRunning
+	}
+
+	log.Info().Str("admin", req.AdminAddress).Msg("Cache warm-up triggered")
+	go func() {
+		defer w.running.Store(false)
+		if _, err := w.run(context.Background()); err != nil {
+			log.Warn().Err(err).Msg("Cache warm-up failed")
+		}
+	}()
+	return nil
+}
+
+// Run prefetches the wallets listed by the sources that are not cached yet
+// Only one run is active at a time.
+func (w *Warmer) Run(ctx context.Context) (*WarmupResult, error) {
+	if !w.running.CompareAndSwap(false, true) {
+		return nil, ErrWarmupRunning
+	}
+	defer w.running.Store(false)
+
+	return w.run(ctx)
+}
+
+func (w *Warmer) run(ctx context.Context) (*WarmupResult, error) {
+	if w.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.policy.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	wallets := w.collect(ctx)
+	result := &WarmupResult{Wallets: len(wallets)}
+
+	// Entries Redis already holds only need to reach this instance's memory
+	// cache, which reading them through the trust store does
+	cached, _ := w.service.trustStorePort.BatchGet(ctx, wallets)
+	var missing []string
+	for _, addr := range wallets {
+		if _, ok := cached[addr]; !ok {
+			missing = append(missing, addr)
+		}
+	}
+	result.Cached = len(wallets) - len(missing)
+	metrics.CacheWarmupWallets.WithLabelValues("cached").Add(float64(result.Cached))
+
+	var (
+		fetched atomic.Int64
+		wg      sync.WaitGroup
+	)
+	batches := make(chan []string)
+	for i := 0; i < w.policy.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range batches {
+				for _, result := range w.service.lookupStatuses(ctx, batch) {
+					if result.Err == nil {
+						fetched.Add(1)
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < len(missing) && ctx.Err() == nil; i += w.policy.BatchSize {
+		end := min(i+w.policy.BatchSize, len(missing))
+		select {
+		case batches <- missing[i:end]:
+		case <-ctx.Done():
+		}
+	}
+	close(batches)
+	wg.Wait()
+
+	result.Fetched = int(fetched.Load())
+	result.Failed = len(missing) - result.Fetched
+	result.Duration = time.Since(start)
+	metrics.CacheWarmupWallets.WithLabelValues("fetched").Add(float64(result.Fetched))
+	metrics.CacheWarmupWallets.WithLabelValues("failed").Add(float64(result.Failed))
+
+	if err := ctx.Err(); err != nil {
+		return result, fmt.Errorf("warm-up interrupted after %d of %d wallets: %w", result.Cached+result.Fetched, result.Wallets, err)
+	}
+	return result, nil
+}
+
+// collect lists the distinct valid wallets of all sources, up to MaxWallets
+// A failing source is skipped so the others can still be used.
+func (w *Warmer) collect(ctx context.Context) []string {
+	seen := make(map[string]struct{})
+	var wallets []string
+
+	for _, source := range w.sources {
+		limit := 0
+		if w.policy.MaxWallets > 0 {
+			limit = w.policy.MaxWallets - len(wallets)
+			if limit <= 0 {
+				break
+			}
+		}
+
+		addrs, err := source.HotWallets(ctx, limit)
+		if err != nil {
+			log.Warn().Err(err).Msg("Failed to list wallets for warm-up")
+			continue
+		}
+
+		for _, addr := range addrs {
+			if limit > 0 && len(wallets) >= w.policy.MaxWallets {
+				break
+			}
+			if _, ok := seen[addr]; ok || !w.service.walletPort.ValidateAddress(addr) {
+				continue
+			}
+			seen[addr] = struct{}{}
+			wallets = append(wallets, addr)
+		}
+	}
+
+	return wallets
+}
+
+// recordAccess counts lookups of wallets for the access-statistics warm-up source
+func (s *Service) recordAccess(walletAddresses ...string) {
+	if s.accessStats != nil {
+		s.accessStats.RecordAccess(walletAddresses...)
+	}
+}
@@ -0,0 +1,181 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"turboauth/internal/domain/auth"
+	"turboauth/pkg/identity"
+)
+
+// walletList is a warm-up source listing fixed wallets
+type walletList []string
+
+func (l walletList) HotWallets(ctx context.Context, limit int) ([]string, error) {
+	if limit > 0 && limit < len(l) {
+		return l[:limit], nil
+	}
+	return l, nil
+}
+
+// testWallets returns n distinct valid wallet addresses
+func testWallets(n int) []string {
+	wallets := make([]string, n)
+	for i := range wallets {
+		wallets[i] = identity.FromPublicKey([identity.PublicKeySize]byte{byte(i), byte(i >> 8), 0x10}, false)
+	}
+	return wallets
+}
+
+// warmupRequest returns a warm-up request signed by the test admin
+func warmupRequest(nonce string) *auth.WarmupRequest {
+	req := &auth.WarmupRequest{
+		AdminAddress: testAdmin,
+		Nonce:        nonce,
+		Timestamp:    time.Now().Unix(),
+	}
+	req.AdminSignature = sign(testAdmin, req.SigningMessage())
+	return req
+}
+
+func TestWarmupFetchesMissingWalletsInBatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      auth.WarmupPolicy
+		wantResult  auth.WarmupResult
+		wantBatches []int
+	}{
+		{
+			name:        "all listed wallets",
+			policy:      auth.WarmupPolicy{BatchSize: 10, Concurrency: 2},
+			wantResult:  auth.WarmupResult{Wallets: 25, Cached: 3, Fetched: 17, Failed: 5},
+			wantBatches: []int{2, 10, 10},
+		},
+		{
+			name:        "capped by MaxWallets",
+			policy:      auth.WarmupPolicy{MaxWallets: 12, BatchSize: 4, Concurrency: 1},
+			wantResult:  auth.WarmupResult{Wallets: 12, Cached: 3, Fetched: 9},
+			wantBatches: []int{1, 4, 4},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The first 20 wallets are known to the contract, and the first 3
+			// of them are already cached
+			wallets := testWallets(25)
+			qubic := newFakeQubic()
+			for _, wallet := range wallets[:20] {
+				qubic.setStatus(wallet, auth.StatusActive, 80)
+			}
+			svc := newTestService(t, qubic)
+			for _, wallet := range wallets[:3] {
+				if _, err := svc.GetStatus(context.Background(), wallet); err != nil {
+					t.Fatalf("GetStatus: %v", err)
+				}
+			}
+
+			// Duplicates and invalid addresses are not fetched
+			source := append(walletList(wallets), "not-a-wallet", wallets[0])
+			result, err := auth.NewWarmer(svc, tt.policy, source).Run(context.Background())
+			if err != nil {
+				t.Fatalf("Run: %v", err)
+			}
+
+			result.Duration = 0
+			if *result != tt.wantResult {
+				t.Errorf("Run = %+v, want %+v", *result, tt.wantResult)
+			}
+			if got := qubic.batchSizes(); !slices.Equal(got, tt.wantBatches) {
+				t.Errorf("batch sizes = %v, want %v", got, tt.wantBatches)
+			}
+
+			// Fetched wallets are served from the cache afterwards
+			queries := qubic.lookups()
+			if _, err := svc.BatchGetStatus(context.Background(), wallets[:tt.wantResult.Wallets-tt.wantResult.Failed]); err != nil {
+				t.Fatalf("BatchGetStatus: %v", err)
+			}
+			if got := qubic.lookups(); got != queries {
+				t.Errorf("blockchain queries after the warm-up = %d, want none", got-queries)
+			}
+		})
+	}
+}
+
+func TestTriggerWarmupRequiresAdminSignature(t *testing.T) {
+	qubic := newFakeQubic()
+	svc := newTestService(t, qubic)
+	warmer := auth.NewWarmer(svc, auth.WarmupPolicy{BatchSize: 10}, walletList(testWallets(5)))
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		req  func() *auth.WarmupRequest
+	}{
+		{
+			name: "not an admin",
+			req: func() *auth.WarmupRequest {
+				req := warmupRequest("not-admin")
+				req.AdminAddress = testWallet
+				req.AdminSignature = sign(testWallet, req.SigningMessage())
+				return req
+			},
+		},
+		{
+			name: "signature over a status change",
+			req: func() *auth.WarmupRequest {
+				req := warmupRequest("status-change")
+				req.AdminSignature = sign(testAdmin, adminRequest(testWallet, auth.StatusActive, 50, req.Nonce).SigningMessage())
+				return req
+			},
+		},
+		{
+			name: "signature over another nonce",
+			req: func() *auth.WarmupRequest {
+				req := warmupRequest("signed-nonce")
+				req.Nonce = "other-nonce"
+				return req
+			},
+		},
+		{
+			name: "expired",
+			req: func() *auth.WarmupRequest {
+				req := warmupRequest("expired")
+				req.Timestamp = time.Now().Add(-time.Hour).Unix()
+				req.AdminSignature = sign(testAdmin, req.SigningMessage())
+				return req
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := warmer.Trigger(ctx, tt.req()); !errors.Is(err, auth.ErrUnauthorized) {
+				t.Errorf("Trigger error = %v, want %v", err, auth.ErrUnauthorized)
+			}
+		})
+	}
+	if got := qubic.lookups(); got != 0 {
+		t.Fatalf("rejected triggers queried %d wallets, want none", got)
+	}
+
+	// A signed request starts a run; while it is held no other run starts
+	release := qubic.hold()
+	if err := warmer.Trigger(ctx, warmupRequest("first")); err != nil {
+		t.Fatalf("Trigger: %v", err)
+	}
+	waitUntil(t, "the warm-up to query the blockchain", func() bool { return qubic.lookups() > 0 })
+	if err := warmer.Trigger(ctx, warmupRequest("second")); !errors.Is(err, auth.ErrWarmupRunning) {
+		t.Errorf("Trigger during a run: error = %v, want %v", err, auth.ErrWarmupRunning)
+	}
+	release()
+	waitUntil(t, "the warm-up to finish", func() bool { return qubic.lookups() == 5 })
+
+	// The nonce of an accepted request cannot be replayed
+	if err := warmer.Trigger(ctx, warmupRequest("first")); !errors.Is(err, auth.ErrUnauthorized) {
+		t.Errorf("Trigger with a used nonce: error = %v, want %v", err, auth.ErrUnauthorized)
+	}
+}
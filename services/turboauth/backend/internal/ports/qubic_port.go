@@ -13,8 +13,14 @@ type QubicPort interface {
 	// SetAuthStatus updates the authentication status on the smart contract
 	SetAuthStatus(ctx context.Context, req *auth.SetStatusRequest) (txHash string, err error)
 
+	// GetTransactionState reports whether a submitted transaction has been
+	// included on-chain, and why it failed if it did
+	GetTransactionState(ctx context.Context, txHash string) (*auth.TxResult, error)
+
 	// BatchGetAuthStatus retrieves multiple statuses in a single call (performance optimization)
-	BatchGetAuthStatus(ctx context.Context, walletAddresses []string) ([]*auth.WalletAuth, error)
+	// It returns one result per wallet, in the order given; an error means
+	// the batch as a whole failed
+	BatchGetAuthStatus(ctx context.Context, walletAddresses []string) ([]auth.StatusResult, error)
 
 	// GetContractAddress returns the current smart contract address
 	GetContractAddress() string
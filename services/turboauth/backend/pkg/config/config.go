@@ -1,8 +1,10 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -14,17 +16,67 @@ type Config struct {
 	Env      string
 
 	// Qubic
-	QubicNodeURL      string
-	QubicContractAddr string
+	QubicNodeURL          string
+	QubicContractAddr     string
+	QubicAdminSeed        string
+	QubicBatchConcurrency int
+	QubicCallTimeout      time.Duration
 
 	// Redis
-	RedisURL      string
-	RedisPassword string
-	RedisDB       int
+	RedisMode                  string
+	RedisAddrs                 []string
+	RedisMasterName            string
+	RedisSentinelPassword      string
+	RedisUsername              string
+	RedisPassword              string
+	RedisDB                    int
+	RedisTLS                   bool
+	RedisTLSCAFile             string
+	RedisTLSCertFile           string
+	RedisTLSKeyFile            string
+	RedisTLSServerName         string
+	RedisTLSInsecureSkipVerify bool
+	RedisKeyPrefix             string
 
 	// Cache
-	CacheTTL       time.Duration
-	UseMemoryCache bool
+	CacheTTL              time.Duration
+	CacheHardTTL          time.Duration
+	NegativeCacheTTL      time.Duration
+	LookupTimeout         time.Duration
+	UseMemoryCache        bool
+	L1CacheTTL            time.Duration
+	MemoryCacheMaxEntries int
+	MemoryCacheMaxBytes   int64
+
+	// Cache warm-up
+	WarmupSources     []string
+	WarmupFile        string
+	WarmupMaxWallets  int
+	WarmupBatchSize   int
+	WarmupConcurrency int
+	WarmupTimeout     time.Duration
+
+	// Wallet verification
+	ChallengeTTL   time.Duration
+	AllowedDomains []string
+	ChainID        string
+
+	// Admin authorization
+	AdminIdentities        []string
+	AdminMaxAge            time.Duration
+	AdminApprovalThreshold int
+	AdminApprovalStatuses  []string
+	ProposalTTL            time.Duration
+
+	// Sessions
+	SessionDefaultTTL      time.Duration
+	SessionMaxTTL          time.Duration
+	SessionCleanupInterval time.Duration
+
+	// Session tokens
+	JWTIssuer   string
+	JWTAudience string
+	JWTKeyFiles []string
 
 	// Logging
 	LogLevel  string
@@ -36,23 +88,71 @@ type Config struct {
 }
 
 // Load reads configuration from environment variables
-func Load() *Config {
-	return &Config{
-		HTTPPort:          getEnvAsInt("HTTP_PORT", 8080),
-		GRPCPort:          getEnvAsInt("GRPC_PORT", 9090),
-		Env:               getEnv("ENV", "development"),
-		QubicNodeURL:      getEnv("QUBIC_NODE_URL", "http://localhost:21841"),
-		QubicContractAddr: getEnv("QUBIC_CONTRACT_ADDRESS", ""),
-		RedisURL:          getEnv("REDIS_URL", "localhost:6379"),
-		RedisPassword:     getEnv("REDIS_PASSWORD", ""),
-		RedisDB:           getEnvAsInt("REDIS_DB", 0),
-		CacheTTL:          time.Duration(getEnvAsInt("CACHE_TTL_SECONDS", 300)) * time.Second,
-		UseMemoryCache:    getEnvAsBool("USE_MEMORY_CACHE", true),
-		LogLevel:          getEnv("LOG_LEVEL", "info"),
-		LogFormat:         getEnv("LOG_FORMAT", "json"),
-		MetricsEnabled:    getEnvAsBool("METRICS_ENABLED", true),
-		MetricsPort:       getEnvAsInt("METRICS_PORT", 2112),
+// Settings without a usable default must be set, or Load returns an error
+// naming the missing variable.
+func Load() (*Config, error) {
+	cfg := &Config{
+		HTTPPort:                   getEnvAsInt("HTTP_PORT", 8080),
+		GRPCPort:                   getEnvAsInt("GRPC_PORT", 9090),
+		Env:                        getEnv("ENV", "development"),
+		QubicNodeURL:               getEnv("QUBIC_NODE_URL", "http://localhost:21841"),
+		QubicContractAddr:          getEnv("QUBIC_CONTRACT_ADDRESS", ""),
+		QubicAdminSeed:             getEnv("QUBIC_ADMIN_SEED", ""),
+		QubicBatchConcurrency:      getEnvAsInt("QUBIC_BATCH_CONCURRENCY", 16),
+		QubicCallTimeout:           time.Duration(getEnvAsInt("QUBIC_CALL_TIMEOUT_SECONDS", 5)) * time.Second,
+		RedisMode:                  getEnv("REDIS_MODE", "standalone"),
+		RedisAddrs:                 getEnvAsSlice("REDIS_URL", []string{"localhost:6379"}),
+		RedisMasterName:            getEnv("REDIS_SENTINEL_MASTER", ""),
+		RedisSentinelPassword:      getEnv("REDIS_SENTINEL_PASSWORD", ""),
+		RedisUsername:              getEnv("REDIS_USERNAME", ""),
+		RedisPassword:              getEnv("REDIS_PASSWORD", ""),
+		RedisDB:                    getEnvAsInt("REDIS_DB", 0),
+		RedisTLS:                   getEnvAsBool("REDIS_TLS_ENABLED", false),
+		RedisTLSCAFile:             getEnv("REDIS_TLS_CA_FILE", ""),
+		RedisTLSCertFile:           getEnv("REDIS_TLS_CERT_FILE", ""),
+		RedisTLSKeyFile:            getEnv("REDIS_TLS_KEY_FILE", ""),
+		RedisTLSServerName:         getEnv("REDIS_TLS_SERVER_NAME", ""),
+		RedisTLSInsecureSkipVerify: getEnvAsBool("REDIS_TLS_INSECURE_SKIP_VERIFY", false),
+		RedisKeyPrefix:             getEnv("REDIS_KEY_PREFIX", ""),
+		CacheTTL:                   time.Duration(getEnvAsInt("CACHE_TTL_SECONDS", 300)) * time.Second,
+		CacheHardTTL:               time.Duration(getEnvAsInt("CACHE_HARD_TTL_SECONDS", 0)) * time.Second,
+		NegativeCacheTTL:           time.Duration(getEnvAsInt("NEGATIVE_CACHE_TTL_SECONDS", 30)) * time.Second,
+		LookupTimeout:              time.Duration(getEnvAsInt("LOOKUP_TIMEOUT_SECONDS", 30)) * time.Second,
+		UseMemoryCache:             getEnvAsBool("USE_MEMORY_CACHE", true),
+		L1CacheTTL:                 time.Duration(getEnvAsInt("L1_CACHE_TTL_SECONDS", 30)) * time.Second,
+		MemoryCacheMaxEntries:      getEnvAsInt("MEMORY_CACHE_MAX_ENTRIES", 100000),
+		MemoryCacheMaxBytes:        int64(getEnvAsInt("MEMORY_CACHE_MAX_BYTES", 64<<20)),
+		WarmupSources:              getEnvAsSlice("WARMUP_SOURCES", []string{"stats", "keyspace"}),
+		WarmupFile:                 getEnv("WARMUP_FILE", ""),
+		WarmupMaxWallets:           getEnvAsInt("WARMUP_MAX_WALLETS", 10000),
+		WarmupBatchSize:            getEnvAsInt("WARMUP_BATCH_SIZE", 100),
+		WarmupConcurrency:          getEnvAsInt("WARMUP_CONCURRENCY", 4),
+		WarmupTimeout:              time.Duration(getEnvAsInt("WARMUP_TIMEOUT_SECONDS", 60)) * time.Second,
+		ChallengeTTL:               time.Duration(getEnvAsInt("CHALLENGE_TTL_SECONDS", 300)) * time.Second,
+		AllowedDomains:             getEnvAsSlice("ALLOWED_DOMAINS", []string{"localhost"}),
+		ChainID:                    getEnv("QUBIC_CHAIN_ID", "mainnet"),
+		AdminIdentities:            getEnvAsSlice("ADMIN_IDENTITIES", nil),
+		AdminMaxAge:                time.Duration(getEnvAsInt("ADMIN_REQUEST_MAX_AGE_SECONDS", 300)) * time.Second,
+		AdminApprovalThreshold:     getEnvAsInt("ADMIN_APPROVAL_THRESHOLD", 1),
+		AdminApprovalStatuses:      getEnvAsSlice("ADMIN_APPROVAL_STATUSES", []string{"BLOCKED"}),
+		ProposalTTL:                time.Duration(getEnvAsInt("PROPOSAL_TTL_SECONDS", 86400)) * time.Second,
+		SessionDefaultTTL:          time.Duration(getEnvAsInt("SESSION_DEFAULT_TTL_SECONDS", 3600)) * time.Second,
+		SessionMaxTTL:              time.Duration(getEnvAsInt("SESSION_MAX_TTL_SECONDS", 86400)) * time.Second,
+		SessionCleanupInterval:     time.Duration(getEnvAsInt("SESSION_CLEANUP_INTERVAL_SECONDS", 300)) * time.Second,
+		JWTIssuer:                  getEnv("JWT_ISSUER", "turboauth"),
+		JWTAudience:                getEnv("JWT_AUDIENCE", ""),
+		JWTKeyFiles:                getEnvAsSlice("JWT_KEY_FILES", nil),
+		LogLevel:                   getEnv("LOG_LEVEL", "info"),
+		LogFormat:                  getEnv("LOG_FORMAT", "json"),
+		MetricsEnabled:             getEnvAsBool("METRICS_ENABLED", true),
+		MetricsPort:                getEnvAsInt("METRICS_PORT", 2112),
+	}
+
+	if cfg.QubicContractAddr == "" {
+		return nil, errors.New("QUBIC_CONTRACT_ADDRESS is required: set it to the identity of the deployed auth contract")
 	}
+
+	return cfg, nil
 }
 
 func getEnv(key, defaultVal string) string {
@@ -77,3 +177,18 @@ func getEnvAsBool(key string, defaultVal bool) bool {
 	}
 	return defaultVal
 }
+
+func getEnvAsSlice(key string, defaultVal []string) []string {
+	valueStr := getEnv(key, "")
+	if valueStr == "" {
+		return defaultVal
+	}
+
+	var values []string
+	for _, value := range strings.Split(valueStr, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
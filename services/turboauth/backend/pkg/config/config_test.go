@@ -0,0 +1,25 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadRequiresContractAddress(t *testing.T) {
+	t.Setenv("QUBIC_CONTRACT_ADDRESS", "")
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "QUBIC_CONTRACT_ADDRESS") {
+		t.Errorf("Load without a contract address: error = %v, want one naming QUBIC_CONTRACT_ADDRESS", err)
+	}
+
+	const contract = "NAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAMAML"
+	t.Setenv("QUBIC_CONTRACT_ADDRESS", contract)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.QubicContractAddr != contract {
+		t.Errorf("QubicContractAddr = %q, want %q", cfg.QubicContractAddr, contract)
+	}
+}
@@ -0,0 +1,102 @@
+package identity
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/cloudflare/circl/xof/k12"
+)
+
+// Length is the number of characters in a Qubic identity
+const Length = 60
+
+// PublicKeySize is the size of a Qubic public key in bytes
+const PublicKeySize = 32
+
+// Identity decoding errors
+var (
+	ErrInvalidIdentity = errors.New("invalid identity")
+	ErrInvalidChecksum = errors.New("invalid identity checksum")
+)
+
+// PublicKey decodes the public key embedded in a Qubic identity and verifies
+// the identity's checksum.
+//
+// An identity encodes the public key as four little-endian 64-bit fragments,
+// each written as 14 base-26 digits (least significant first) using the
+// letters A-Z, followed by a 4-character checksum of the public key.
+func PublicKey(id string) ([PublicKeySize]byte, error) {
+	var publicKey [PublicKeySize]byte
+
+	if len(id) != Length {
+		return publicKey, ErrInvalidIdentity
+	}
+
+	for i := 0; i < 4; i++ {
+		var fragment uint64
+		for j := 13; j >= 0; j-- {
+			c := id[i*14+j]
+			if c < 'A' || c > 'Z' {
+				return publicKey, ErrInvalidIdentity
+			}
+			fragment = fragment*26 + uint64(c-'A')
+		}
+		binary.LittleEndian.PutUint64(publicKey[i*8:], fragment)
+	}
+
+	// Re-encoding also rejects fragments that overflowed 64 bits (26^14 > 2^64),
+	// since they do not round-trip
+	if FromPublicKey(publicKey, false) != id {
+		for _, c := range []byte(id[56:]) {
+			if c < 'A' || c > 'Z' {
+				return publicKey, ErrInvalidIdentity
+			}
+		}
+		return publicKey, ErrInvalidChecksum
+	}
+
+	return publicKey, nil
+}
+
+// Validate reports whether id is a well-formed identity with a valid checksum
+func Validate(id string) error {
+	_, err := PublicKey(id)
+	return err
+}
+
+// FromPublicKey encodes a public key as a Qubic identity, including its
+// 4-character K12 checksum. Transaction ids use the lowercase form.
+func FromPublicKey(publicKey [PublicKeySize]byte, lowercase bool) string {
+	base := byte('A')
+	if lowercase {
+		base = 'a'
+	}
+
+	id := make([]byte, Length)
+	for i := 0; i < 4; i++ {
+		fragment := binary.LittleEndian.Uint64(publicKey[i*8:])
+		for j := 0; j < 14; j++ {
+			id[i*14+j] = byte(fragment%26) + base
+			fragment /= 26
+		}
+	}
+
+	checksum := checksum(publicKey)
+	for i := 0; i < 4; i++ {
+		id[56+i] = byte(checksum%26) + base
+		checksum /= 26
+	}
+
+	return string(id)
+}
+
+// checksum returns the 18-bit K12 checksum of a public key
+func checksum(publicKey [PublicKeySize]byte) uint32 {
+	h := k12.NewDraft10(nil)
+	_, _ = h.Write(publicKey[:])
+
+	var digest [3]byte
+	_, _ = h.Read(digest[:])
+
+	return (uint32(digest[0]) | uint32(digest[1])<<8 | uint32(digest[2])<<16) & 0x3FFFF
+}
@@ -0,0 +1,83 @@
+package identity
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// vectorIdentity is the identity of the 55-letter seed "aaa…a"
+const (
+	vectorIdentity  = "BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK"
+	vectorPublicKey = "1f590d03e613bdded38b4c0820ac44615f91af12435980b3ede3c08c315a2544"
+)
+
+func TestPublicKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		wantErr error
+	}{
+		{name: "valid", id: vectorIdentity},
+		{name: "bad checksum", id: vectorIdentity[:59] + "A", wantErr: ErrInvalidChecksum},
+		{name: "typo in public key", id: "C" + vectorIdentity[1:], wantErr: ErrInvalidChecksum},
+		{name: "fragment overflowing 64 bits", id: strings.Repeat("Z", 14) + vectorIdentity[14:], wantErr: ErrInvalidChecksum},
+		{name: "lowercase", id: strings.ToLower(vectorIdentity), wantErr: ErrInvalidIdentity},
+		{name: "non-letter", id: vectorIdentity[:10] + "1" + vectorIdentity[11:], wantErr: ErrInvalidIdentity},
+		{name: "non-letter in checksum", id: vectorIdentity[:59] + "1", wantErr: ErrInvalidIdentity},
+		{name: "too short", id: vectorIdentity[:59], wantErr: ErrInvalidIdentity},
+		{name: "too long", id: vectorIdentity + "A", wantErr: ErrInvalidIdentity},
+		{name: "empty", id: "", wantErr: ErrInvalidIdentity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publicKey, err := PublicKey(tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("PublicKey error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if got := hex.EncodeToString(publicKey[:]); got != vectorPublicKey {
+				t.Errorf("PublicKey = %s, want %s", got, vectorPublicKey)
+			}
+			if err := Validate(tt.id); err != nil {
+				t.Errorf("Validate: %v", err)
+			}
+		})
+	}
+}
+
+func TestFromPublicKey(t *testing.T) {
+	var publicKey [PublicKeySize]byte
+	raw, _ := hex.DecodeString(vectorPublicKey)
+	copy(publicKey[:], raw)
+
+	if got := FromPublicKey(publicKey, false); got != vectorIdentity {
+		t.Errorf("FromPublicKey = %s, want %s", got, vectorIdentity)
+	}
+	if got := FromPublicKey(publicKey, true); got != strings.ToLower(vectorIdentity) {
+		t.Errorf("FromPublicKey lowercase = %s, want %s", got, strings.ToLower(vectorIdentity))
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, publicKey := range [][PublicKeySize]byte{
+		{},
+		{1},
+		{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+			0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	} {
+		id := FromPublicKey(publicKey, false)
+		got, err := PublicKey(id)
+		if err != nil {
+			t.Errorf("PublicKey(%s): %v", id, err)
+			continue
+		}
+		if got != publicKey {
+			t.Errorf("PublicKey(FromPublicKey(%x)) = %x", publicKey, got)
+		}
+	}
+}
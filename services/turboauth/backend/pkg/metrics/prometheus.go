@@ -59,6 +59,69 @@ var (
 		[]string{"layer"},
 	)
 
+	CacheStaleServed = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "microauth_cache_stale_served_total",
+			Help: "Total number of cached statuses served past their TTL",
+		},
+		[]string{"reason"}, // revalidate (refresh started) or error (refresh failed)
+	)
+
+	CacheNegativeHits = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "microauth_cache_negative_hits_total",
+			Help: "Total number of lookups answered by a cached unknown-wallet entry",
+		},
+	)
+
+	CacheEvictions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "microauth_cache_evictions_total",
+			Help: "Total number of cache entries evicted",
+		},
+		[]string{"layer", "reason"}, // capacity or expired
+	)
+
+	CacheEntries = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "microauth_cache_entries",
+			Help: "Number of entries held by a cache layer",
+		},
+		[]string{"layer"},
+	)
+
+	CacheWarmupWallets = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "microauth_cache_warmup_wallets_total",
+			Help: "Wallets handled by cache warm-up runs by result",
+		},
+		[]string{"result"},
+	)
+
+	CacheErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "microauth_cache_errors_total",
+			Help: "Total number of cache operations that failed",
+		},
+		[]string{"layer", "operation"},
+	)
+
+	CacheLayerUp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "microauth_cache_layer_up",
+			Help: "Whether a cache layer is available (1) or bypassed (0)",
+		},
+		[]string{"layer"},
+	)
+
+	CacheInvalidations = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "microauth_cache_invalidations_total",
+			Help: "Total number of memory cache invalidations received from other instances or flushes",
+		},
+		[]string{"reason"}, // remote (single entry) or reconnect/resubscribe (full flush)
+	)
+
 	// Blockchain Metrics
 	BlockchainRequestsTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -68,6 +131,14 @@ var (
 		[]string{"operation", "status"},
 	)
 
+	BlockchainRequestsCoalesced = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "microauth_blockchain_requests_coalesced_total",
+			Help: "Total number of blockchain lookups served by joining an identical in-flight lookup",
+		},
+		[]string{"operation"},
+	)
+
 	BlockchainRequestDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "microauth_blockchain_request_duration_seconds",
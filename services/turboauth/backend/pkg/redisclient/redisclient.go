@@ -0,0 +1,260 @@
+package redisclient
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Deployment modes
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
+)
+
+// globEscaper escapes the characters SCAN patterns treat specially
+var globEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`, "[", `\[`, "]", `\]`)
+
+// pingTimeout bounds the connection test when a client is created
+const pingTimeout = 5 * time.Second
+
+// Options describes how to reach Redis
+type Options struct {
+	// Mode is standalone, sentinel or cluster
+	Mode string
+
+	// Addrs is the server address in standalone mode, the sentinel
+	// addresses in sentinel mode and the seed nodes in cluster mode
+	Addrs []string
+
+	// MasterName is the name of the master monitored by the sentinels
+	MasterName       string
+	SentinelPassword string
+
+	Username string
+	Password string
+	DB       int // not supported in cluster mode
+	PoolSize int // zero uses the go-redis default
+
+	TLS                   bool
+	TLSCAFile             string // verifies the server against this CA instead of the system roots
+	TLSCertFile           string // client certificate for mutual TLS
+	TLSKeyFile            string
+	TLSServerName         string
+	TLSInsecureSkipVerify bool
+
+	// KeyPrefix namespaces every key and channel, so several environments can
+	// share one deployment
+	KeyPrefix string
+}
+
+// Validate reports configuration errors that retrying cannot fix
+func (o Options) Validate() error {
+	if len(o.Addrs) == 0 {
+		return errors.New("redis: no address configured")
+	}
+
+	switch o.mode() {
+	case ModeStandalone:
+		if len(o.Addrs) > 1 {
+			return errors.New("redis: standalone mode takes a single address")
+		}
+	case ModeSentinel:
+		if o.MasterName == "" {
+			return errors.New("redis: sentinel mode requires a master name")
+		}
+	case ModeCluster:
+		if o.DB != 0 {
+			return errors.New("redis: cluster mode only supports database 0")
+		}
+	default:
+		return fmt.Errorf("redis: unknown mode %q", o.Mode)
+	}
+
+	_, err := o.tlsConfig()
+	return err
+}
+
+// New creates a client for the configured mode and tests the connection
+func New(opts Options) (redis.UniversalClient, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	tlsConfig, err := opts.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	var client redis.UniversalClient
+	switch opts.mode() {
+	case ModeSentinel:
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       opts.MasterName,
+			SentinelAddrs:    opts.Addrs,
+			SentinelPassword: opts.SentinelPassword,
+			Username:         opts.Username,
+			Password:         opts.Password,
+			DB:               opts.DB,
+			PoolSize:         opts.PoolSize,
+			TLSConfig:        tlsConfig,
+		})
+	case ModeCluster:
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     opts.Addrs,
+			Username:  opts.Username,
+			Password:  opts.Password,
+			PoolSize:  opts.PoolSize,
+			TLSConfig: tlsConfig,
+		})
+	default:
+		client = redis.NewClient(&redis.Options{
+			Addr:      opts.Addrs[0],
+			Username:  opts.Username,
+			Password:  opts.Password,
+			DB:        opts.DB,
+			PoolSize:  opts.PoolSize,
+			TLSConfig: tlsConfig,
+		})
+	}
+
+	// Test connection
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+
+	return client, nil
+}
+
+// Keyspace returns the key layout for these options
+func (o Options) Keyspace() Keyspace {
+	return Keyspace{
+		prefix:  o.KeyPrefix,
+		cluster: o.mode() == ModeCluster,
+	}
+}
+
+func (o Options) mode() string {
+	if o.Mode == "" {
+		return ModeStandalone
+	}
+	return strings.ToLower(o.Mode)
+}
+
+// tlsConfig builds the TLS configuration, or returns nil if TLS is disabled
+func (o Options) tlsConfig() (*tls.Config, error) {
+	if !o.TLS {
+		return nil, nil
+	}
+
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         o.TLSServerName,
+		InsecureSkipVerify: o.TLSInsecureSkipVerify,
+	}
+
+	if o.TLSCAFile != "" {
+		pem, err := os.ReadFile(o.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("redis: failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("redis: no certificates found in CA file")
+		}
+		config.RootCAs = pool
+	}
+
+	if o.TLSCertFile != "" || o.TLSKeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.TLSCertFile, o.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("redis: failed to load client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
+// Keyspace builds the keys and channels of one deployment
+//
+// Keys are "<prefix><namespace>:<id>" in every mode, so in cluster mode the
+// keys of a namespace are spread over all slots. Pipelines spanning several
+// keys are split by node; multi-key commands must be split by Slot, and
+// transactions must stay within one key or use GroupKey.
+type Keyspace struct {
+	prefix  string
+	cluster bool
+}
+
+// Key returns the key of id in namespace
+func (k Keyspace) Key(namespace, id string) string {
+	return k.prefix + namespace + ":" + id
+}
+
+// GroupKey returns the key of id in namespace for namespaces whose keys are
+// combined by multi-key commands. In cluster mode the namespace is wrapped in
+// a hash tag, "{<prefix><namespace>}:<id>", which puts all of its keys in one
+// slot, so it is only meant for namespaces of a few keys. Standalone and
+// sentinel deployments get the same key as Key.
+func (k Keyspace) GroupKey(namespace, id string) string {
+	if k.cluster {
+		return "{" + k.prefix + namespace + "}:" + id
+	}
+	return k.Key(namespace, id)
+}
+
+// Slot returns the cluster slot of key, honouring hash tags; outside cluster
+// mode every key is in slot 0, so keys grouped by slot form a single group
+func (k Keyspace) Slot(key string) int {
+	if !k.cluster {
+		return 0
+	}
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key) % clusterSlots)
+}
+
+// Pattern returns a SCAN pattern matching every key of namespace
+func (k Keyspace) Pattern(namespace string) string {
+	return globEscaper.Replace(k.Key(namespace, "")) + "*"
+}
+
+// Channel returns the name of a pub/sub channel
+func (k Keyspace) Channel(name string) string {
+	return k.prefix + name
+}
+
+// clusterSlots is the number of hash slots of a Redis Cluster
+const clusterSlots = 16384
+
+// crc16 is the CRC-16/XMODEM checksum Redis Cluster hashes keys with
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
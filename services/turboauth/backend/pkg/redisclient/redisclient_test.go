@@ -0,0 +1,93 @@
+package redisclient
+
+import "testing"
+
+func TestKeyspace(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     Options
+		key      string
+		groupKey string
+		pattern  string
+	}{
+		{
+			name:     "standalone",
+			opts:     Options{KeyPrefix: "prod:"},
+			key:      "prod:auth:WALLET",
+			groupKey: "prod:access:42",
+			pattern:  "prod:auth:*",
+		},
+		{
+			name:     "sentinel",
+			opts:     Options{Mode: ModeSentinel},
+			key:      "auth:WALLET",
+			groupKey: "access:42",
+			pattern:  "auth:*",
+		},
+		{
+			name:     "cluster",
+			opts:     Options{Mode: ModeCluster, KeyPrefix: "prod:"},
+			key:      "prod:auth:WALLET",
+			groupKey: "{prod:access}:42",
+			pattern:  "prod:auth:*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := tt.opts.Keyspace()
+
+			if got := keys.Key("auth", "WALLET"); got != tt.key {
+				t.Errorf("Key = %q, want %q", got, tt.key)
+			}
+			if got := keys.GroupKey("access", "42"); got != tt.groupKey {
+				t.Errorf("GroupKey = %q, want %q", got, tt.groupKey)
+			}
+			if got := keys.Pattern("auth"); got != tt.pattern {
+				t.Errorf("Pattern = %q, want %q", got, tt.pattern)
+			}
+		})
+	}
+}
+
+func TestKeysSpreadOverClusterSlots(t *testing.T) {
+	keys := Options{Mode: ModeCluster}.Keyspace()
+
+	// Keys of one namespace must not all land in the slot of a shared hash tag
+	slots := make(map[int]bool)
+	for _, id := range []string{"A", "B", "C", "D", "E", "F", "G", "H"} {
+		slots[keys.Slot(keys.Key("auth", id))] = true
+	}
+	if len(slots) < 2 {
+		t.Errorf("8 keys of a namespace map to %d slot(s)", len(slots))
+	}
+
+	if keys.Slot(keys.GroupKey("access", "1")) != keys.Slot(keys.GroupKey("access", "2")) {
+		t.Error("group keys of a namespace map to different slots")
+	}
+}
+
+func TestSlot(t *testing.T) {
+	cluster := Options{Mode: ModeCluster}.Keyspace()
+
+	// Slots reported by CLUSTER KEYSLOT
+	tests := []struct {
+		key  string
+		slot int
+	}{
+		{key: "foo", slot: 12182},
+		{key: "{foo}:bar", slot: 12182},
+		{key: "{}foo", slot: 9500}, // an empty tag hashes the whole key
+		{key: "123456789", slot: 12739},
+	}
+	for _, tt := range tests {
+		if got := cluster.Slot(tt.key); got != tt.slot {
+			t.Errorf("Slot(%q) = %d, want %d", tt.key, got, tt.slot)
+		}
+	}
+
+	standalone := Options{}.Keyspace()
+	if got := standalone.Slot("foo"); got != 0 {
+		t.Errorf("standalone Slot = %d, want 0", got)
+	}
+}
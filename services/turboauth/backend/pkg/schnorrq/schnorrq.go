@@ -0,0 +1,144 @@
+package schnorrq
+
+import (
+	"crypto/subtle"
+	"math/big"
+
+	"github.com/cloudflare/circl/ecc/fourq"
+	"github.com/cloudflare/circl/xof/k12"
+)
+
+// Sizes of SchnorrQ keys, digests and signatures in bytes
+const (
+	PublicKeySize = 32
+	DigestSize    = 32
+	SignatureSize = 64
+)
+
+// order is the order of the FourQ generator point
+var order = fourq.Params().N
+
+// cofactorInverse is 392^-1 mod N. fourq.Point.ScalarMult clears the cofactor
+// (multiplies by 392) before multiplying, so scalars are pre-multiplied by it.
+var cofactorInverse = new(big.Int).ModInverse(big.NewInt(392), order)
+
+// PublicKey derives the public key for a private key (P = privateKey * G)
+func PublicKey(privateKey [32]byte) [PublicKeySize]byte {
+	scalar := reduce(privateKey[:])
+
+	var P fourq.Point
+	P.ScalarBaseMult(&scalar)
+
+	var publicKey [PublicKeySize]byte
+	P.Marshal(&publicKey)
+	return publicKey
+}
+
+// Sign produces a SchnorrQ signature over a message digest the same way the
+// Qubic node does: the private scalar and nonce seed are both expanded from
+// the 32-byte subseed.
+func Sign(subseed [32]byte, publicKey [PublicKeySize]byte, digest [DigestSize]byte) [SignatureSize]byte {
+	k := kangarooTwelve(subseed[:], 64)
+
+	// Deterministic nonce r = K12(k[32:64] || digest) mod N, R = r * G
+	r := reduce(kangarooTwelve(append(append([]byte{}, k[32:]...), digest[:]...), 64))
+	var R fourq.Point
+	R.ScalarBaseMult(&r)
+
+	var signature [SignatureSize]byte
+	var encodedR [32]byte
+	R.Marshal(&encodedR)
+	copy(signature[:32], encodedR[:])
+
+	// s = r - h * a mod N, with h = K12(R || publicKey || digest) mod N
+	h := challenge(encodedR[:], publicKey[:], digest[:])
+	a := leToInt(k[:32])
+
+	s := new(big.Int).Mul(h, a)
+	s.Sub(leToInt(r[:]), s)
+	s.Mod(s, order)
+
+	sBytes := intToLE(s)
+	copy(signature[32:], sBytes[:])
+	return signature
+}
+
+// Verify checks a SchnorrQ signature over a message digest: it accepts when
+// s*G + h*A encodes to R, with h = K12(R || publicKey || digest) mod N.
+func Verify(publicKey [PublicKeySize]byte, digest [DigestSize]byte, signature [SignatureSize]byte) bool {
+	// Reject non-canonical encodings the same way FourQlib does: the top bit
+	// of each point's first field element must be clear and s < 2^246
+	if publicKey[15]&0x80 != 0 || signature[15]&0x80 != 0 || signature[62]&0xC0 != 0 || signature[63] != 0 {
+		return false
+	}
+
+	// FourQlib stops there, which still accepts s + N for a valid s; only the
+	// reduced scalar is accepted, so a signature has a single valid encoding
+	if leToInt(signature[32:]).Cmp(order) >= 0 {
+		return false
+	}
+
+	var A fourq.Point
+	if !A.Unmarshal(&publicKey) {
+		return false
+	}
+
+	h := challenge(signature[:32], publicKey[:], digest[:])
+	h.Mul(h, cofactorInverse).Mod(h, order)
+	hBytes := intToLE(h)
+
+	var s [32]byte
+	copy(s[:], signature[32:])
+
+	var sG, hA, R fourq.Point
+	sG.ScalarBaseMult(&s)
+	hA.ScalarMult(&hBytes, &A)
+	R.Add(&sG, &hA)
+
+	var encodedR [32]byte
+	R.Marshal(&encodedR)
+	return subtle.ConstantTimeCompare(encodedR[:], signature[:32]) == 1
+}
+
+// challenge computes h = K12(R || publicKey || digest) mod N
+func challenge(encodedR, publicKey, digest []byte) *big.Int {
+	buf := make([]byte, 0, 96)
+	buf = append(buf, encodedR...)
+	buf = append(buf, publicKey...)
+	buf = append(buf, digest...)
+	h := leToInt(kangarooTwelve(buf, 64))
+	return h.Mod(h, order)
+}
+
+// reduce interprets b as a little-endian integer and reduces it modulo N
+func reduce(b []byte) [32]byte {
+	return intToLE(new(big.Int).Mod(leToInt(b), order))
+}
+
+// leToInt converts a little-endian byte slice to a big.Int
+func leToInt(b []byte) *big.Int {
+	be := make([]byte, len(b))
+	for i := range b {
+		be[len(b)-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(be)
+}
+
+// intToLE converts a non-negative big.Int smaller than 2^256 to 32 little-endian bytes
+func intToLE(x *big.Int) [32]byte {
+	var out [32]byte
+	be := x.FillBytes(make([]byte, 32))
+	for i := range be {
+		out[31-i] = be[i]
+	}
+	return out
+}
+
+// kangarooTwelve computes a KangarooTwelve digest of the given size
+func kangarooTwelve(data []byte, size int) []byte {
+	h := k12.NewDraft10(nil)
+	_, _ = h.Write(data)
+	out := make([]byte, size)
+	_, _ = h.Read(out)
+	return out
+}
@@ -0,0 +1,169 @@
+package schnorrq
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// The test vector is the key of the 55-letter seed "aaa…a", whose identity is
+// BZBQFLLBNCXEMGLOBHUVFTLUPLVCPQUASSILFABOFFBCADQSSUPNWLZBQEXK, signing the
+// K12 digest of vectorMessage.
+const (
+	vectorMessage   = "TurboAuth test vector"
+	vectorPublicKey = "1f590d03e613bdded38b4c0820ac44615f91af12435980b3ede3c08c315a2544"
+	vectorSignature = "3878d3c910bafea9485d8d685c250c064da6a920e4d4afc15e8bc40bec78019c" +
+		"829ef09c1f68bc7974c8ee2370a797bcb0466439c386f7c990887050246b0800"
+
+	// vectorUnreducedScalar is the vector's s + N, the same point multiple
+	vectorUnreducedScalar = "692b67642ebc6ea90d42fe21bea7549c965b20d6451e4bba0393ce9ee5363200"
+)
+
+// vectorSubseed derives the subseed of the vector's seed as Qubic wallets do
+func vectorSubseed() [32]byte {
+	seed := make([]byte, 55) // 'a' - 'a' for every letter
+	var subseed [32]byte
+	copy(subseed[:], kangarooTwelve(seed, 32))
+	return subseed
+}
+
+func decodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	raw, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("decoding %q: %v", s, err)
+	}
+	return raw
+}
+
+func vectorKey(t *testing.T) [PublicKeySize]byte {
+	var publicKey [PublicKeySize]byte
+	copy(publicKey[:], decodeHex(t, vectorPublicKey))
+	return publicKey
+}
+
+func vectorSig(t *testing.T) [SignatureSize]byte {
+	var signature [SignatureSize]byte
+	copy(signature[:], decodeHex(t, vectorSignature))
+	return signature
+}
+
+func vectorDigest() [DigestSize]byte {
+	var digest [DigestSize]byte
+	copy(digest[:], kangarooTwelve([]byte(vectorMessage), DigestSize))
+	return digest
+}
+
+func TestPublicKey(t *testing.T) {
+	subseed := vectorSubseed()
+
+	var privateKey [32]byte
+	copy(privateKey[:], kangarooTwelve(subseed[:], 32))
+
+	got := PublicKey(privateKey)
+	if want := vectorKey(t); got != want {
+		t.Errorf("PublicKey = %x, want %x", got, want)
+	}
+}
+
+func TestSign(t *testing.T) {
+	publicKey := vectorKey(t)
+
+	// Signing is deterministic
+	got := Sign(vectorSubseed(), publicKey, vectorDigest())
+	if want := vectorSig(t); got != want {
+		t.Errorf("Sign = %x, want %x", got, want)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(publicKey *[PublicKeySize]byte, digest *[DigestSize]byte, signature *[SignatureSize]byte)
+		want   bool
+	}{
+		{
+			name:   "valid",
+			tamper: func(*[PublicKeySize]byte, *[DigestSize]byte, *[SignatureSize]byte) {},
+			want:   true,
+		},
+		{
+			name: "tampered digest",
+			tamper: func(_ *[PublicKeySize]byte, digest *[DigestSize]byte, _ *[SignatureSize]byte) {
+				digest[0] ^= 0x01
+			},
+		},
+		{
+			name: "tampered R",
+			tamper: func(_ *[PublicKeySize]byte, _ *[DigestSize]byte, signature *[SignatureSize]byte) {
+				signature[0] ^= 0x01
+			},
+		},
+		{
+			name: "tampered s",
+			tamper: func(_ *[PublicKeySize]byte, _ *[DigestSize]byte, signature *[SignatureSize]byte) {
+				signature[32] ^= 0x01
+			},
+		},
+		{
+			name: "unreduced scalar",
+			tamper: func(_ *[PublicKeySize]byte, _ *[DigestSize]byte, signature *[SignatureSize]byte) {
+				s, _ := hex.DecodeString(vectorUnreducedScalar)
+				copy(signature[32:], s)
+			},
+		},
+		{
+			name: "scalar of 2^246 or more",
+			tamper: func(_ *[PublicKeySize]byte, _ *[DigestSize]byte, signature *[SignatureSize]byte) {
+				signature[63] = 0x01
+			},
+		},
+		{
+			name: "non-canonical R",
+			tamper: func(_ *[PublicKeySize]byte, _ *[DigestSize]byte, signature *[SignatureSize]byte) {
+				signature[15] |= 0x80
+			},
+		},
+		{
+			name: "non-canonical public key",
+			tamper: func(publicKey *[PublicKeySize]byte, _ *[DigestSize]byte, _ *[SignatureSize]byte) {
+				publicKey[15] |= 0x80
+			},
+		},
+		{
+			name: "other public key",
+			tamper: func(publicKey *[PublicKeySize]byte, _ *[DigestSize]byte, _ *[SignatureSize]byte) {
+				*publicKey = PublicKey([32]byte{1})
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publicKey := vectorKey(t)
+			digest := vectorDigest()
+			signature := vectorSig(t)
+			tt.tamper(&publicKey, &digest, &signature)
+
+			if got := Verify(publicKey, digest, signature); got != tt.want {
+				t.Errorf("Verify = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	for _, message := range []string{"", "a", strings.Repeat("turboauth", 100)} {
+		var subseed, privateKey [32]byte
+		copy(subseed[:], kangarooTwelve([]byte(message+"subseed"), 32))
+		copy(privateKey[:], kangarooTwelve(subseed[:], 32))
+		publicKey := PublicKey(privateKey)
+
+		var digest [DigestSize]byte
+		copy(digest[:], kangarooTwelve([]byte(message), DigestSize))
+
+		if !Verify(publicKey, digest, Sign(subseed, publicKey, digest)) {
+			t.Errorf("signature over %q does not verify", message)
+		}
+	}
+}